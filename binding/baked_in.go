@@ -4,9 +4,10 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-// IsMobile 验证是否是手机号
+// IsMobile 验证是否是中国大陆手机号(11 位, 不含国际区号), 包括虚拟运营商号段.
+// 号段见 phone_metadata.json, 其它地区或格式见 IsMobileOf.
 func IsMobile(s string) bool {
-	return rxPhone.MatchString(s)
+	return len(s) == 11 && isDigits(s) && IsMobileOf(s, "CN")
 }
 
 // IsDecimal 是否是 decimal
@@ -61,14 +62,12 @@ func IsNumberGte0(s string) bool {
 	return rxNumberGte0.MatchString(s)
 }
 
+// parseString2Decimal 解析 s 和 t, t 由调用方传入, 取值不受限制, 因此不使用标签参数的缓存.
 func parseString2Decimal(s, t string) (d, tt decimal.Decimal, err error) {
 	d, err = decimal.NewFromString(s)
 	if err != nil {
 		return
 	}
 	tt, err = decimal.NewFromString(t)
-	if err != nil {
-		panic(err.Error())
-	}
 	return
 }
@@ -0,0 +1,161 @@
+package binding
+
+import (
+	_ "embed" // embed bank bin table
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// bankBinJSON 内置的银行卡 BIN 表示例, 只收录了部分银行的常见 BIN, 不能用于判断卡号是否真实存在.
+// 生产环境应使用 LoadBankBins 或 SetBankBins 加载完整的 BIN 表.
+//
+//go:embed bank_bin_sample.json
+var bankBinJSON []byte
+
+// CardScheme 卡组织
+type CardScheme string
+
+// CardScheme defined
+const (
+	CardUnionPay   CardScheme = "unionpay"
+	CardVisa       CardScheme = "visa"
+	CardMastercard CardScheme = "mastercard"
+)
+
+// cardRange 卡组织的 BIN 范围, 比较卡号前 len(low) 位.
+type cardRange struct {
+	scheme  CardScheme
+	low     string
+	high    string
+	lengths []int
+}
+
+// cardRanges 卡组织的 BIN 范围和卡号长度
+var cardRanges = []cardRange{
+	{CardUnionPay, "62", "62", []int{16, 17, 18, 19}},
+	{CardVisa, "4", "4", []int{13, 16, 19}},
+	{CardMastercard, "51", "55", []int{16}},
+	{CardMastercard, "2221", "2720", []int{16}},
+}
+
+// BankBin 银行卡 BIN 信息
+type BankBin struct {
+	// BIN 发卡行识别码, 卡号前缀
+	BIN string `json:"bin"`
+	// Bank 银行代码, 如 ICBC
+	Bank string `json:"bank"`
+	// Name 银行名称
+	Name string `json:"name"`
+	// Type 卡类型, debit 或 credit
+	Type string `json:"type"`
+}
+
+var bankBins atomic.Value // map[string]*BankBin
+
+func init() {
+	if err := LoadBankBins(strings.NewReader(string(bankBinJSON))); err != nil {
+		panic(err)
+	}
+}
+
+// LoadBankBins 从 json 中加载 BIN 表, 替换当前的 BIN 表, 格式同内置的 bank_bin_sample.json.
+func LoadBankBins(r io.Reader) error {
+	var bins []*BankBin
+	if err := json.NewDecoder(r).Decode(&bins); err != nil {
+		return fmt.Errorf("bankcard: %w", err)
+	}
+	return SetBankBins(bins)
+}
+
+// SetBankBins 替换当前的 BIN 表, 并发安全.
+func SetBankBins(bins []*BankBin) error {
+	mp := make(map[string]*BankBin, len(bins))
+	for _, b := range bins {
+		if b == nil || len(b.BIN) < 4 || !isDigits(b.BIN) {
+			return fmt.Errorf("bankcard: invalid bin %+v", b)
+		}
+		mp[b.BIN] = b
+	}
+	bankBins.Store(mp)
+	return nil
+}
+
+// LookupBankBin 根据卡号按最长前缀查找 BIN 信息, 不校验卡号.
+func LookupBankBin(number string) (*BankBin, bool) {
+	bins := bankBins.Load().(map[string]*BankBin)
+	for n := len(number); n >= 4; n-- {
+		if b, ok := bins[number[:n]]; ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// IsLuhn 是否是数字串且满足 Luhn 校验
+func IsLuhn(s string) bool {
+	if len(s) < 2 || !isDigits(s) {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		d := int(s[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// CardSchemeOf 根据 BIN 范围和卡号长度获取卡组织, 不校验 Luhn.
+func CardSchemeOf(number string) (CardScheme, bool) {
+	if !isDigits(number) {
+		return "", false
+	}
+	for _, r := range cardRanges {
+		if len(number) < len(r.low) || !containsInt(r.lengths, len(number)) {
+			continue
+		}
+		prefix := number[:len(r.low)]
+		if prefix >= r.low && prefix <= r.high {
+			return r.scheme, true
+		}
+	}
+	return "", false
+}
+
+// IsBankCard 是否是银联, Visa 或万事达卡号, 校验 BIN 范围, 长度和 Luhn.
+// 指定 schemes 时, 卡组织必须是其中之一.
+func IsBankCard(number string, schemes ...CardScheme) bool {
+	scheme, ok := CardSchemeOf(number)
+	if !ok || !IsLuhn(number) {
+		return false
+	}
+	if len(schemes) == 0 {
+		return true
+	}
+	for _, s := range schemes {
+		if s == scheme {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBankCardInBins 卡号前缀是否在当前加载的 BIN 表中, 同时校验长度(16~19 位)和 Luhn.
+// 内置的 BIN 表只是示例, 见 LoadBankBins.
+func IsBankCardInBins(number string) bool {
+	if len(number) < 16 || len(number) > 19 || !IsLuhn(number) {
+		return false
+	}
+	_, ok := LookupBankBin(number)
+	return ok
+}
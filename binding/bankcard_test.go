@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// withLuhn 追加 Luhn 校验位
+func withLuhn(s string) string {
+	for d := 0; d <= 9; d++ {
+		if c := strconv.Itoa(d); IsLuhn(s + c) {
+			return s + c
+		}
+	}
+	return ""
+}
+
+func TestIsLuhn(t *testing.T) {
+	require.True(t, IsLuhn("79927398713"))
+	require.False(t, IsLuhn("79927398710"))
+	require.False(t, IsLuhn("7992739871a"))
+	require.False(t, IsLuhn("0"))
+}
+
+func TestBankCard(t *testing.T) {
+	tests := []struct {
+		number string
+		scheme CardScheme
+	}{
+		{"4111111111111111", CardVisa},
+		{"4222222222222", CardVisa},
+		{"5555555555554444", CardMastercard},
+		{"2223003122003222", CardMastercard},
+		{withLuhn("622202100000000000"), CardUnionPay},
+		{withLuhn("621700000000000"), CardUnionPay},
+	}
+	for _, tt := range tests {
+		scheme, ok := CardSchemeOf(tt.number)
+		require.True(t, ok, tt.number)
+		require.Equal(t, tt.scheme, scheme, tt.number)
+		require.True(t, IsBankCard(tt.number), tt.number)
+		require.True(t, IsBankCard(tt.number, tt.scheme), tt.number)
+	}
+	require.False(t, IsBankCard("4111111111111111", CardUnionPay))
+	require.False(t, IsBankCard("4111111111111112"))          // luhn
+	require.False(t, IsBankCard("378282246310005"))           // american express
+	require.False(t, IsBankCard(withLuhn("272100000000000"))) // 超出万事达范围
+	require.False(t, IsBankCard(withLuhn("41111111111111")))  // 长度
+}
+
+func TestLookupBankBin(t *testing.T) {
+	b, ok := LookupBankBin("6222021234567890123")
+	require.True(t, ok)
+	require.Equal(t, "ICBC", b.Bank)
+	require.Equal(t, "debit", b.Type)
+
+	require.True(t, IsBankCardInBins(withLuhn("622848000000000000")))
+	require.False(t, IsBankCardInBins("62284800000000000x"))
+	require.False(t, IsBankCardInBins(withLuhn("999999000000000000")))
+
+	old := bankBins.Load()
+	defer bankBins.Store(old)
+	require.NoError(t, LoadBankBins(strings.NewReader(`[{"bin": "999999", "bank": "TEST"}]`)))
+	require.True(t, IsBankCardInBins(withLuhn("999999000000000000")))
+	require.Error(t, LoadBankBins(strings.NewReader(`[{"bin": "99"}]`)))
+}
+
+func TestValidPayment(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type Payout struct {
+		Card     string `validate:"omitempty,bank_card"`
+		Visa     string `validate:"omitempty,bank_card=visa mastercard"`
+		BinCard  string `validate:"omitempty,bank_card_bin"`
+		IBAN     string `validate:"omitempty,iban"`
+		SwiftBic string `validate:"omitempty,swift_bic"`
+	}
+	require.NoError(t, valid.Struct(&Payout{
+		Card:     withLuhn("622202100000000000"),
+		Visa:     "5555555555554444",
+		BinCard:  withLuhn("622588000000000"),
+		IBAN:     "GB82 WEST 1234 5698 7654 32",
+		SwiftBic: "BKCHCNBJ",
+	}))
+
+	err := valid.Struct(&Payout{
+		Card:     "4111111111111112",
+		Visa:     withLuhn("622202100000000000"),
+		BinCard:  "4111111111111111",
+		IBAN:     "GB82WEST12345698765431",
+		SwiftBic: "BKCH",
+	})
+	require.Equal(t, []string{
+		"Card:bank_card",
+		"Visa:bank_card",
+		"BinCard:bank_card_bin",
+		"IBAN:iban",
+		"SwiftBic:swift_bic",
+	}, fieldErrorTags(t, err))
+}
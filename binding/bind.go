@@ -0,0 +1,260 @@
+package binding
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// MIME types
+const (
+	MIMEJSON              = "application/json"
+	MIMEXML               = "application/xml"
+	MIMEXML2              = "text/xml"
+	MIMEPOSTForm          = "application/x-www-form-urlencoded"
+	MIMEMultipartPOSTForm = "multipart/form-data"
+)
+
+// defaultMaxMemory multipart 表单默认最大内存, 超出部分存放在临时文件中.
+const defaultMaxMemory = 32 << 20
+
+// defaultMaxBodySize 请求 body 默认最大长度
+const defaultMaxBodySize = 32 << 20
+
+// error defined
+var (
+	ErrBindTarget           = errors.New("binding: target must be a non-nil pointer to struct")
+	ErrUnsupportedMediaType = errors.New("binding: unsupported media type")
+)
+
+// BindError 绑定错误.
+// 校验失败时 Source 为 validate, Err 为 validator.ValidationErrors, 可以使用 TranslateErrors 翻译.
+type BindError struct {
+	// Source 出错的来源, 如 query, header, form, json, xml, default, modify, validate
+	Source string
+	// Field 出错的结构体字段名, 解码整个 body 或校验出错时为空
+	Field string
+	// Err 原始错误
+	Err error
+}
+
+// Error implement error
+func (e *BindError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("binding: %s field %q: %v", e.Source, e.Field, e.Err)
+	}
+	return fmt.Sprintf("binding: %s: %v", e.Source, e.Err)
+}
+
+// Unwrap implement errors.Unwrap
+func (e *BindError) Unwrap() error { return e.Err }
+
+// BinderOption binder option
+type BinderOption func(*Binder)
+
+// WithValidator 使用自定义的校验器, 校验器需要已使用 RegisterValidation 注册.
+func WithValidator(valid *validator.Validate) BinderOption {
+	return func(b *Binder) {
+		b.valid = valid
+	}
+}
+
+// WithValidationOptions 未指定校验器时, 注册默认校验器使用的选项, 如 WithRequestStructs.
+func WithValidationOptions(opts ...ValidationOption) BinderOption {
+	return func(b *Binder) {
+		b.validOpts = append(b.validOpts, opts...)
+	}
+}
+
+// WithModifier 使用自定义的 Modifier, 默认使用 RegisterModifier 注册的默认 Modifier.
+func WithModifier(m *Modifier) BinderOption {
+	return func(b *Binder) {
+		b.modifier = m
+	}
+}
+
+// WithMaxMemory multipart 表单最大内存, 默认 32MB.
+func WithMaxMemory(n int64) BinderOption {
+	return func(b *Binder) {
+		b.maxMemory = n
+	}
+}
+
+// WithMaxBodySize 请求 body 最大长度, 包括 multipart 表单中的文件, 超出时返回错误, 小于等于 0 时不限制, 默认 32MB.
+func WithMaxBodySize(n int64) BinderOption {
+	return func(b *Binder) {
+		b.maxBodySize = n
+	}
+}
+
+// Binder 从 http 请求中解码数据到结构体, 并进行校验.
+//
+// 依次处理:
+//   - default 标签: 零值字段的默认值
+//   - query 标签: url 查询参数
+//   - header 标签: 请求头
+//   - body: 根据 Content-Type 选择 json, xml, 表单(form 标签)或 multipart 表单(form 标签, 支持文件)
+//   - 修饰: mod 标签, 见 Modifier
+//   - 校验: validate 标签
+type Binder struct {
+	valid       *validator.Validate
+	modifier    *Modifier
+	maxMemory   int64
+	maxBodySize int64
+	validOpts   []ValidationOption
+}
+
+// NewBinder 新建 Binder, 未指定校验器时使用注册了 RegisterValidation 的默认校验器.
+func NewBinder(opts ...BinderOption) (*Binder, error) {
+	b := &Binder{modifier: defaultModifier, maxMemory: defaultMaxMemory, maxBodySize: defaultMaxBodySize}
+	for _, opt := range opts {
+		opt(b)
+	}
+	if b.valid == nil {
+		b.valid = validator.New()
+		if err := RegisterValidation(b.valid, b.validOpts...); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+var (
+	defaultBinder     *Binder
+	defaultBinderErr  error
+	defaultBinderOnce sync.Once
+)
+
+// Bind 使用默认的 Binder 绑定请求, 见 Binder.
+func Bind(r *http.Request, dst any) error {
+	defaultBinderOnce.Do(func() {
+		defaultBinder, defaultBinderErr = NewBinder()
+	})
+	if defaultBinderErr != nil {
+		return defaultBinderErr
+	}
+	return defaultBinder.Bind(r, dst)
+}
+
+// Validator 校验器
+func (b *Binder) Validator() *validator.Validate { return b.valid }
+
+// Bind 从请求中解码数据到 dst 并校验, dst 必须为结构体指针.
+func (b *Binder) Bind(r *http.Request, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrBindTarget
+	}
+	v := rv.Elem()
+
+	if err := setDefaults(v); err != nil {
+		return err
+	}
+	query := r.URL.Query()
+	err := mapFields(v, "query", func(name string) ([]string, bool) {
+		values, ok := query[name]
+		return values, ok
+	})
+	if err != nil {
+		return err
+	}
+	err = mapFields(v, "header", func(name string) ([]string, bool) {
+		values := r.Header.Values(name)
+		return values, len(values) > 0
+	})
+	if err != nil {
+		return err
+	}
+	if err = b.bindBody(r, v, dst); err != nil {
+		return err
+	}
+	if err = b.modifier.Struct(dst); err != nil {
+		return &BindError{Source: "modify", Err: err}
+	}
+	if err = b.valid.Struct(dst); err != nil {
+		return &BindError{Source: "validate", Err: err}
+	}
+	return nil
+}
+
+func (b *Binder) bindBody(r *http.Request, v reflect.Value, dst any) error {
+	if r.Body == nil || r.Body == http.NoBody || r.ContentLength == 0 {
+		return nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return &BindError{Source: "body", Err: ErrUnsupportedMediaType}
+	}
+	if b.maxBodySize > 0 {
+		r.Body = http.MaxBytesReader(nil, r.Body, b.maxBodySize)
+	}
+
+	switch mediaType {
+	case MIMEJSON:
+		if err = json.NewDecoder(r.Body).Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+			return &BindError{Source: "json", Err: err}
+		}
+	case MIMEXML, MIMEXML2:
+		if err = xml.NewDecoder(r.Body).Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+			return &BindError{Source: "xml", Err: err}
+		}
+	case MIMEPOSTForm:
+		if err = r.ParseForm(); err != nil {
+			return &BindError{Source: "form", Err: err}
+		}
+		return mapFields(v, "form", func(name string) ([]string, bool) {
+			values, ok := r.PostForm[name]
+			return values, ok
+		})
+	case MIMEMultipartPOSTForm:
+		if err = r.ParseMultipartForm(b.maxMemory); err != nil {
+			return &BindError{Source: "form", Err: err}
+		}
+		err = mapFields(v, "form", func(name string) ([]string, bool) {
+			values, ok := r.MultipartForm.Value[name]
+			return values, ok
+		})
+		if err != nil {
+			return err
+		}
+		return mapFiles(v, r.MultipartForm.File)
+	default:
+		return &BindError{Source: "body", Err: fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mediaType)}
+	}
+	return nil
+}
+
+// mapFiles 设置 form 标签中类型为 *multipart.FileHeader 或 []*multipart.FileHeader 的字段.
+func mapFiles(v reflect.Value, files map[string][]*multipart.FileHeader) error {
+	_, err := walkFields(v, "form", func(field reflect.Value, _ reflect.StructField, tagValue string) (bool, error) {
+		name, _, _ := strings.Cut(tagValue, ",")
+		fhs := files[name]
+		if len(fhs) == 0 {
+			return false, nil
+		}
+		switch {
+		case field.Type() == fileHeaderType:
+			field.Set(reflect.ValueOf(fhs[0]))
+		case isFileField(field.Type()):
+			field.Set(reflect.ValueOf(fhs))
+		default:
+			return false, nil
+		}
+		return true, nil
+	})
+	return err
+}
@@ -0,0 +1,173 @@
+package binding
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+type Paging struct {
+	Page    int `query:"page" default:"1" validate:"gte=1"`
+	PerPage int `query:"per_page" default:"20" validate:"lte=100"`
+}
+
+type CreateOrder struct {
+	Paging
+	RequestID string          `header:"X-Request-Id" validate:"required"`
+	Tags      []string        `query:"tag" default:"a,b"`
+	Mobile    string          `json:"mobile" xml:"mobile" form:"mobile" validate:"mobile"`
+	Amount    decimal.Decimal `json:"amount" xml:"amount" form:"amount" validate:"decimal_gt=0"`
+	Remark    *string         `json:"remark" xml:"remark" form:"remark"`
+}
+
+func TestBindJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/orders?per_page=50&tag=x&tag=y",
+		strings.NewReader(`{"mobile":"13800138000","amount":"9.99","remark":"hi"}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	r.Header.Set("X-Request-Id", "req-1")
+
+	var o CreateOrder
+	require.NoError(t, Bind(r, &o))
+	require.Equal(t, 1, o.Page)
+	require.Equal(t, 50, o.PerPage)
+	require.Equal(t, "req-1", o.RequestID)
+	require.Equal(t, []string{"x", "y"}, o.Tags)
+	require.Equal(t, "13800138000", o.Mobile)
+	require.True(t, o.Amount.Equal(decimal.RequireFromString("9.99")))
+	require.Equal(t, "hi", *o.Remark)
+}
+
+func TestBindXML(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/orders",
+		strings.NewReader(`<CreateOrder><mobile>13800138000</mobile><amount>1</amount></CreateOrder>`))
+	r.Header.Set("Content-Type", "application/xml")
+	r.Header.Set("X-Request-Id", "req-1")
+
+	var o CreateOrder
+	require.NoError(t, Bind(r, &o))
+	require.Equal(t, []string{"a", "b"}, o.Tags)
+	require.Equal(t, "13800138000", o.Mobile)
+	require.Nil(t, o.Remark)
+}
+
+func TestBindForm(t *testing.T) {
+	form := url.Values{"mobile": {"13800138000"}, "amount": {"0.5"}, "remark": {"r"}}
+	r := httptest.NewRequest(http.MethodPost, "/orders?page=2", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Request-Id", "req-1")
+
+	var o CreateOrder
+	require.NoError(t, Bind(r, &o))
+	require.Equal(t, 2, o.Page)
+	require.Equal(t, "13800138000", o.Mobile)
+	require.True(t, o.Amount.Equal(decimal.RequireFromString("0.5")))
+	require.Equal(t, "r", *o.Remark)
+}
+
+func TestBindMultipart(t *testing.T) {
+	type Upload struct {
+		Name        string                  `form:"name" validate:"required"`
+		Avatar      *multipart.FileHeader   `form:"avatar" validate:"required"`
+		Attachments []*multipart.FileHeader `form:"attachments"`
+	}
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	require.NoError(t, mw.WriteField("name", "clip"))
+	for _, f := range []struct{ field, name, content string }{
+		{"avatar", "a.png", "png"},
+		{"attachments", "1.txt", "one"},
+		{"attachments", "2.txt", "two"},
+	} {
+		w, err := mw.CreateFormFile(f.field, f.name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	var u Upload
+	require.NoError(t, Bind(r, &u))
+	require.Equal(t, "clip", u.Name)
+	require.Equal(t, "a.png", u.Avatar.Filename)
+	require.Len(t, u.Attachments, 2)
+	f, err := u.Attachments[1].Open()
+	require.NoError(t, err)
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, "two", string(content))
+}
+
+func TestBindError(t *testing.T) {
+	newRequest := func(query, contentType, body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/orders"+query, strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("X-Request-Id", "req-1")
+		return r
+	}
+	var bindErr *BindError
+
+	// 校验错误
+	err := Bind(newRequest("?per_page=101", MIMEJSON, `{"mobile":"123","amount":"1"}`), &CreateOrder{})
+	require.ErrorAs(t, err, &bindErr)
+	require.Equal(t, "validate", bindErr.Source)
+	var errs validator.ValidationErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 2)
+
+	// 字段解码错误
+	err = Bind(newRequest("?page=x", MIMEJSON, `{}`), &CreateOrder{})
+	require.ErrorAs(t, err, &bindErr)
+	require.Equal(t, "query", bindErr.Source)
+	require.Equal(t, "Page", bindErr.Field)
+
+	// body 解码错误
+	err = Bind(newRequest("", MIMEJSON, `{"amount":"x"}`), &CreateOrder{})
+	require.ErrorAs(t, err, &bindErr)
+	require.Equal(t, "json", bindErr.Source)
+
+	err = Bind(newRequest("", "text/plain", `x`), &CreateOrder{})
+	require.ErrorIs(t, err, ErrUnsupportedMediaType)
+
+	require.ErrorIs(t, Bind(newRequest("", MIMEJSON, `{}`), CreateOrder{}), ErrBindTarget)
+	require.ErrorIs(t, Bind(newRequest("", MIMEJSON, `{}`), (*CreateOrder)(nil)), ErrBindTarget)
+
+	type BadDefault struct {
+		Page int `default:"x"`
+	}
+	b, err := NewBinder(WithValidator(newTestValidate(t)), WithMaxMemory(1<<20))
+	require.NoError(t, err)
+	err = b.Bind(newRequest("", MIMEJSON, `{}`), &BadDefault{})
+	require.ErrorAs(t, err, &bindErr)
+	require.Equal(t, "default", bindErr.Source)
+	require.Equal(t, "Page", bindErr.Field)
+
+	// body 超出长度
+	b, err = NewBinder(WithValidator(newTestValidate(t)), WithMaxBodySize(16))
+	require.NoError(t, err)
+	err = b.Bind(newRequest("", MIMEJSON, `{"mobile":"13800138000","amount":"1"}`), &CreateOrder{})
+	require.ErrorAs(t, err, &bindErr)
+	require.Equal(t, "json", bindErr.Source)
+	err = b.Bind(newRequest("", MIMEPOSTForm, `mobile=13800138000&amount=1`), &CreateOrder{})
+	require.ErrorAs(t, err, &bindErr)
+	require.Equal(t, "form", bindErr.Source)
+
+	_, err = NewBinder(WithValidationOptions(WithRequestStructs(struct {
+		Amount string `validate:"decimal_gt=x"`
+	}{})))
+	require.Error(t, err)
+}
@@ -0,0 +1,431 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// 时间格式
+const (
+	// DateLayout 日期格式
+	DateLayout = "2006-01-02"
+	// ClockLayout 时刻格式
+	ClockLayout = "15:04"
+	// maxUnixSeconds 9999-12-31T23:59:59Z
+	maxUnixSeconds = 253402300799
+)
+
+var (
+	// timeBoundParamCache 已解析的时间边界参数, param -> timeBound
+	timeBoundParamCache sync.Map
+	// durationParamCache 已解析的时长参数, param -> time.Duration
+	durationParamCache sync.Map
+	// clockRangeParamCache 已解析的时刻范围参数, param -> [2]time.Duration
+	clockRangeParamCache sync.Map
+	// weekdaysParamCache 已解析的星期参数, param -> [7]bool
+	weekdaysParamCache sync.Map
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// timeBound 时间边界, base 为 now, today 或空(绝对时间).
+type timeBound struct {
+	base   string
+	abs    string
+	offset time.Duration
+}
+
+// parseTimeBoundParam 解析时间边界参数, 解析结果会被缓存.
+// 支持 now, today(当天零点), 可带有偏移量, 如 now+24h, today-720h, 以及 RFC 3339 或 2006-01-02 格式的绝对时间.
+func parseTimeBoundParam(param string) (timeBound, error) {
+	if v, ok := timeBoundParamCache.Load(param); ok {
+		return v.(timeBound), nil
+	}
+	var b timeBound
+	switch {
+	case strings.HasPrefix(param, "now"), strings.HasPrefix(param, "today"):
+		b.base = "now"
+		if strings.HasPrefix(param, "today") {
+			b.base = "today"
+		}
+		if offset := param[len(b.base):]; offset != "" {
+			if offset[0] != '+' && offset[0] != '-' {
+				return b, fmt.Errorf("time param %q has invalid offset", param)
+			}
+			d, err := time.ParseDuration(offset)
+			if err != nil {
+				return b, fmt.Errorf("time param %q has invalid offset, %w", param, err)
+			}
+			b.offset = d
+		}
+	default:
+		if _, err := parseTimeString(param, time.UTC); err != nil {
+			return b, fmt.Errorf("time param %q must be now, today or a RFC 3339 or 2006-01-02 time", param)
+		}
+		b.abs = param
+	}
+	timeBoundParamCache.Store(param, b)
+	return b, nil
+}
+
+// parseDurationParam 解析时长参数, 如 720h, 必须大于 0, 解析结果会被缓存.
+func parseDurationParam(param string) (time.Duration, error) {
+	if v, ok := durationParamCache.Load(param); ok {
+		return v.(time.Duration), nil
+	}
+	d, err := time.ParseDuration(param)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration param %q must be greater than 0", param)
+	}
+	durationParamCache.Store(param, d)
+	return d, nil
+}
+
+// parseClockRangeParam 解析时刻范围参数 15:04~15:04, 返回距零点的时长, 解析结果会被缓存.
+func parseClockRangeParam(param string) (start, end time.Duration, err error) {
+	if v, ok := clockRangeParamCache.Load(param); ok {
+		r := v.([2]time.Duration)
+		return r[0], r[1], nil
+	}
+	startStr, endStr, ok := strings.Cut(param, "~")
+	if !ok {
+		return 0, 0, fmt.Errorf("clock param %q must be 15:04~15:04", param)
+	}
+	if start, err = parseClock(startStr); err != nil {
+		return 0, 0, err
+	}
+	if end, err = parseClock(endStr); err != nil {
+		return 0, 0, err
+	}
+	clockRangeParamCache.Store(param, [2]time.Duration{start, end})
+	return start, end, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse(ClockLayout, s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// parseWeekdaysParam 解析以空格分隔的星期参数, 支持 0~6(0 为周日) 和 sun~sat, 解析结果会被缓存.
+func parseWeekdaysParam(param string) ([7]bool, error) {
+	if v, ok := weekdaysParamCache.Load(param); ok {
+		return v.([7]bool), nil
+	}
+	var days [7]bool
+	fields := strings.Fields(param)
+	if len(fields) == 0 {
+		return days, fmt.Errorf("weekday param must not be empty")
+	}
+	for _, s := range fields {
+		if d, ok := weekdayNames[strings.ToLower(s)]; ok {
+			days[d] = true
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 || n > 6 {
+			return days, fmt.Errorf("weekday param %q is invalid", s)
+		}
+		days[n] = true
+	}
+	weekdaysParamCache.Store(param, days)
+	return days, nil
+}
+
+func checkTimeBoundParam(param string) error {
+	_, err := parseTimeBoundParam(param)
+	return err
+}
+
+func checkDurationParam(param string) error {
+	_, err := parseDurationParam(param)
+	return err
+}
+
+func checkClockRangeParam(param string) error {
+	_, _, err := parseClockRangeParam(param)
+	return err
+}
+
+func checkWeekdaysParam(param string) error {
+	_, err := parseWeekdaysParam(param)
+	return err
+}
+
+// parseTimeString 解析 RFC 3339, 2006-01-02(loc 时区的零点) 或 unix 秒格式的时间.
+func parseTimeString(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(DateLayout, s, loc); err == nil {
+		return t, nil
+	}
+	if n, ok := parseUnixSeconds(s); ok {
+		return time.Unix(n, 0).In(loc), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+func parseUnixSeconds(s string) (int64, bool) {
+	if !isDigits(s) {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	return n, err == nil && n <= maxUnixSeconds
+}
+
+// IsRFC3339 是否是 RFC 3339 格式的时间, 如 2006-01-02T15:04:05+08:00.
+func IsRFC3339(s string) bool {
+	_, err := time.Parse(time.RFC3339, s)
+	return err == nil
+}
+
+// IsUnixSeconds 是否是 unix 秒, 范围为 0 ~ 9999-12-31T23:59:59Z.
+func IsUnixSeconds(s string) bool {
+	_, ok := parseUnixSeconds(s)
+	return ok
+}
+
+// IsDate 是否是 2006-01-02 格式的日期
+func IsDate(s string) bool {
+	_, err := time.Parse(DateLayout, s)
+	return err == nil
+}
+
+// TimeOption 时间校验选项
+type TimeOption func(*TimeValidator)
+
+// WithTimeLocation 时区, 用于解析不带时区的日期, 计算 today 以及时刻和星期, 默认为 UTC.
+// 服务器时区与业务时区无关, 需要按本地日期或时刻校验时应显式指定, 如 Asia/Shanghai.
+func WithTimeLocation(loc *time.Location) TimeOption {
+	return func(tv *TimeValidator) {
+		tv.loc = loc
+	}
+}
+
+// WithTimeNow 当前时间, 默认为 time.Now, 用于测试.
+func WithTimeNow(now func() time.Time) TimeOption {
+	return func(tv *TimeValidator) {
+		tv.now = now
+	}
+}
+
+// TimeValidator 时间校验, 可以指定时区和当前时间, 默认为 UTC 和 time.Now.
+//
+// 支持的字段类型: time.Time, RFC 3339, 2006-01-02 或 unix 秒格式的字符串(包括 json.Number),
+// 作为 unix 秒的整型, 以及它们的指针.
+type TimeValidator struct {
+	loc *time.Location
+	now func() time.Time
+}
+
+// NewTimeValidator 新建 TimeValidator
+func NewTimeValidator(opts ...TimeOption) *TimeValidator {
+	tv := &TimeValidator{loc: time.UTC, now: time.Now}
+	for _, opt := range opts {
+		opt(tv)
+	}
+	return tv
+}
+
+// defaultTimeValidator RegisterValidation 默认使用的时间校验
+var defaultTimeValidator = NewTimeValidator()
+
+// ValidAfter 校验时间是否晚于参数, 如 time_after=now, time_after=today+24h, time_after=2024-01-01.
+func (tv *TimeValidator) ValidAfter(fl validator.FieldLevel) bool {
+	return tv.validBound(fl, func(t, b time.Time) bool { return t.After(b) })
+}
+
+// ValidBefore 校验时间是否早于参数, 参数同 ValidAfter.
+func (tv *TimeValidator) ValidBefore(fl validator.FieldLevel) bool {
+	return tv.validBound(fl, func(t, b time.Time) bool { return t.Before(b) })
+}
+
+// ValidMin 校验时间是否不早于参数, 如 time_min=today, 参数同 ValidAfter.
+func (tv *TimeValidator) ValidMin(fl validator.FieldLevel) bool {
+	return tv.validBound(fl, func(t, b time.Time) bool { return !t.Before(b) })
+}
+
+// ValidMax 校验时间是否不晚于参数, 如 time_max=now, 参数同 ValidAfter.
+func (tv *TimeValidator) ValidMax(fl validator.FieldLevel) bool {
+	return tv.validBound(fl, func(t, b time.Time) bool { return !t.After(b) })
+}
+
+// ValidWithin 校验时间与当前时间相差是否不超过参数, 如 time_within=720h.
+// 与 time_after=now 一起使用时, 表示未来 720 小时内. 参数为 time.ParseDuration 的格式, 不支持 d.
+func (tv *TimeValidator) ValidWithin(fl validator.FieldLevel) bool {
+	d, err := parseDurationParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	t, ok := tv.fieldTime(fl.Field())
+	if !ok {
+		return false
+	}
+	diff := t.Sub(tv.now())
+	return diff >= -d && diff <= d
+}
+
+// ValidGtField 校验时间是否晚于同一结构体中的另一字段, 如 time_gtfield=StartAt.
+func (tv *TimeValidator) ValidGtField(fl validator.FieldLevel) bool {
+	return tv.validField(fl, func(t, o time.Time) bool { return t.After(o) })
+}
+
+// ValidGteField 校验时间是否不早于同一结构体中的另一字段
+func (tv *TimeValidator) ValidGteField(fl validator.FieldLevel) bool {
+	return tv.validField(fl, func(t, o time.Time) bool { return !t.Before(o) })
+}
+
+// ValidLtField 校验时间是否早于同一结构体中的另一字段
+func (tv *TimeValidator) ValidLtField(fl validator.FieldLevel) bool {
+	return tv.validField(fl, func(t, o time.Time) bool { return t.Before(o) })
+}
+
+// ValidLteField 校验时间是否不晚于同一结构体中的另一字段
+func (tv *TimeValidator) ValidLteField(fl validator.FieldLevel) bool {
+	return tv.validField(fl, func(t, o time.Time) bool { return !t.After(o) })
+}
+
+// ValidTimeOfDay 校验时间在时区中的时刻是否在闭区间内, 如 time_of_day=09:00~18:00.
+// 开始时刻晚于结束时刻时表示跨越零点, 如 time_of_day=22:00~06:00.
+func (tv *TimeValidator) ValidTimeOfDay(fl validator.FieldLevel) bool {
+	start, end, err := parseClockRangeParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	t, ok := tv.fieldTime(fl.Field())
+	if !ok {
+		return false
+	}
+	t = t.In(tv.loc)
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	if start <= end {
+		return clock >= start && clock <= end
+	}
+	return clock >= start || clock <= end
+}
+
+// ValidWeekday 校验时间在时区中是否是指定的星期, 如 time_weekday=mon tue wed thu fri, 或 time_weekday=1 2 3 4 5.
+func (tv *TimeValidator) ValidWeekday(fl validator.FieldLevel) bool {
+	days, err := parseWeekdaysParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	t, ok := tv.fieldTime(fl.Field())
+	return ok && days[t.In(tv.loc).Weekday()]
+}
+
+// validBound 字段与时间边界比较, 参数无效时 panic, 注册时使用 WithRequestStructs 可以提前返回错误.
+func (tv *TimeValidator) validBound(fl validator.FieldLevel, cmp func(t, b time.Time) bool) bool {
+	b, err := parseTimeBoundParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	t, ok := tv.fieldTime(fl.Field())
+	return ok && cmp(t, tv.boundTime(b))
+}
+
+// validField 字段与另一字段比较, 另一字段不存在或不是有效的时间时校验失败.
+func (tv *TimeValidator) validField(fl validator.FieldLevel, cmp func(t, o time.Time) bool) bool {
+	t, ok := tv.fieldTime(fl.Field())
+	if !ok {
+		return false
+	}
+	other, _, _, found := fl.GetStructFieldOK2()
+	if !found {
+		return false
+	}
+	o, ok, supported := tv.timeFromField(other)
+	if !supported || !ok {
+		return false
+	}
+	return cmp(t, o)
+}
+
+func (tv *TimeValidator) boundTime(b timeBound) time.Time {
+	switch b.base {
+	case "now":
+		return tv.now().Add(b.offset)
+	case "today":
+		now := tv.now().In(tv.loc)
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, tv.loc).Add(b.offset)
+	default:
+		t, _ := parseTimeString(b.abs, tv.loc)
+		return t
+	}
+}
+
+// fieldTime 获取字段的时间, 不支持的字段类型 panic.
+func (tv *TimeValidator) fieldTime(field reflect.Value) (time.Time, bool) {
+	t, ok, supported := tv.timeFromField(field)
+	if !supported {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+	return t, ok
+}
+
+// timeFromField 获取字段的时间, ok 表示是否是有效的时间, supported 表示是否是支持的字段类型.
+func (tv *TimeValidator) timeFromField(field reflect.Value) (t time.Time, ok, supported bool) {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return t, false, true
+		}
+		field = field.Elem()
+	}
+	if field.Type() == timeType {
+		return field.Interface().(time.Time), true, true
+	}
+	switch field.Kind() {
+	case reflect.String:
+		t, err := parseTimeString(field.String(), tv.loc)
+		return t, err == nil, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := field.Int()
+		return time.Unix(n, 0).In(tv.loc), n >= 0 && n <= maxUnixSeconds, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := field.Uint()
+		return time.Unix(int64(n), 0).In(tv.loc), n <= maxUnixSeconds, true
+	default:
+		return t, false, false
+	}
+}
+
+// ValidIsRFC3339 校验是否为 RFC 3339 格式的时间字符串
+func ValidIsRFC3339(fl validator.FieldLevel) bool {
+	return IsRFC3339(fl.Field().String())
+}
+
+// ValidIsUnixSeconds 校验是否为 unix 秒, 支持字符串和整型.
+func ValidIsUnixSeconds(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.String:
+		return IsUnixSeconds(field.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() >= 0 && field.Int() <= maxUnixSeconds
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field.Uint() <= maxUnixSeconds
+	default:
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+}
+
+// ValidIsDate 校验是否为 2006-01-02 格式的日期字符串
+func ValidIsDate(fl validator.FieldLevel) bool {
+	return IsDate(fl.Field().String())
+}
@@ -0,0 +1,158 @@
+package binding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTime(t *testing.T) {
+	require.True(t, IsRFC3339("2024-01-02T15:04:05+08:00"))
+	require.True(t, IsRFC3339("2024-01-02T15:04:05.123Z"))
+	require.False(t, IsRFC3339("2024-01-02 15:04:05"))
+	require.False(t, IsRFC3339("2024-01-02"))
+
+	require.True(t, IsUnixSeconds("0"))
+	require.True(t, IsUnixSeconds("1704182645"))
+	require.True(t, IsUnixSeconds("253402300799"))
+	require.False(t, IsUnixSeconds("253402300800"))
+	require.False(t, IsUnixSeconds("-1"))
+	require.False(t, IsUnixSeconds("1.5"))
+
+	require.True(t, IsDate("2024-02-29"))
+	require.False(t, IsDate("2023-02-29"))
+	require.False(t, IsDate("2024-1-2"))
+}
+
+func TestParseTimeParams(t *testing.T) {
+	b, err := parseTimeBoundParam("today-720h")
+	require.NoError(t, err)
+	require.Equal(t, timeBound{base: "today", offset: -720 * time.Hour}, b)
+	b, err = parseTimeBoundParam("2024-01-02")
+	require.NoError(t, err)
+	require.Equal(t, timeBound{abs: "2024-01-02"}, b)
+	for _, param := range []string{"", "now1h", "now+1d", "tomorrow", "2024-13-01"} {
+		_, err = parseTimeBoundParam(param)
+		require.Error(t, err, param)
+	}
+
+	start, end, err := parseClockRangeParam("09:00~18:30")
+	require.NoError(t, err)
+	require.Equal(t, 9*time.Hour, start)
+	require.Equal(t, 18*time.Hour+30*time.Minute, end)
+	for _, param := range []string{"09:00", "9~18", "09:00~24:00"} {
+		_, _, err = parseClockRangeParam(param)
+		require.Error(t, err, param)
+	}
+
+	days, err := parseWeekdaysParam("Mon 2 fri")
+	require.NoError(t, err)
+	require.Equal(t, [7]bool{false, true, true, false, false, true, false}, days)
+	for _, param := range []string{"", "7", "monday"} {
+		_, err = parseWeekdaysParam(param)
+		require.Error(t, err, param)
+	}
+
+	for _, param := range []string{"0s", "-1h", "30d"} {
+		_, err = parseDurationParam(param)
+		require.Error(t, err, param)
+	}
+}
+
+func TestValidTime(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	// 2024-01-02(周二) 10:00 +08:00
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, shanghai)
+	valid := validator.New()
+	require.NoError(t, RegisterValidation(valid, WithTimeValidator(NewTimeValidator(
+		WithTimeLocation(shanghai),
+		WithTimeNow(func() time.Time { return now }),
+	))))
+
+	type Booking struct {
+		Date      string     `validate:"time_date,time_min=today,time_within=720h"`
+		StartAt   string     `validate:"time_rfc3339,time_after=now,time_of_day=09:00~18:00,time_weekday=mon tue wed thu fri"`
+		EndAt     time.Time  `validate:"time_gtfield=StartAt,time_before=now+720h"`
+		CreatedAt int64      `validate:"time_unix,time_max=now"`
+		PaidAt    *time.Time `validate:"omitempty,time_gtefield=StartAt"`
+		Birthday  string     `validate:"omitempty,time_date,time_before=2010-01-01"`
+	}
+	require.NoError(t, valid.Struct(&Booking{
+		Date:      "2024-01-02",
+		StartAt:   "2024-01-03T09:00:00+08:00",
+		EndAt:     time.Date(2024, 1, 3, 2, 0, 0, 0, time.UTC),
+		CreatedAt: now.Unix(),
+		Birthday:  "2000-01-01",
+	}))
+
+	paid := now
+	err = valid.Struct(&Booking{
+		Date:      "2024-01-01",
+		StartAt:   "2024-01-03T00:30:00Z",
+		EndAt:     time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		CreatedAt: now.Unix() + 1,
+		PaidAt:    &paid,
+		Birthday:  "2010-01-01",
+	})
+	require.Equal(t, []string{
+		"Date:time_min",
+		"StartAt:time_of_day",
+		"EndAt:time_gtfield",
+		"CreatedAt:time_max",
+		"PaidAt:time_gtefield",
+		"Birthday:time_before",
+	}, fieldErrorTags(t, err))
+
+	err = valid.Struct(&Booking{
+		Date:      "2024-03-01",
+		StartAt:   "2024-01-06T09:00:00+08:00",
+		EndAt:     now.Add(721 * time.Hour),
+		CreatedAt: -1,
+	})
+	require.Equal(t, []string{
+		"Date:time_within",
+		"StartAt:time_weekday",
+		"EndAt:time_before",
+		"CreatedAt:time_unix",
+	}, fieldErrorTags(t, err))
+
+	// 默认使用 time.Now
+	type Event struct {
+		At time.Time `validate:"time_after=now"`
+	}
+	def := newTestValidate(t)
+	require.NoError(t, def.Struct(&Event{At: time.Now().Add(time.Hour)}))
+	require.Error(t, def.Struct(&Event{At: time.Now().Add(-time.Hour)}))
+
+	require.Panics(t, func() {
+		_ = def.Struct(&struct {
+			At float64 `validate:"time_after=now"`
+		}{})
+	})
+	type BadWithin struct {
+		At string `validate:"time_within=1d"`
+	}
+	type BadClock struct {
+		At time.Time `validate:"time_of_day=25:00~26:00"`
+	}
+	require.Panics(t, func() { _ = def.Struct(&BadWithin{At: "2024-01-02T10:00:00+08:00"}) })
+	require.Panics(t, func() { _ = def.Struct(&BadClock{At: now}) })
+
+	// 注册时检查参数
+	err = RegisterValidation(validator.New(), WithRequestStructs(&BadWithin{}, &BadClock{}, &struct {
+		At   string `validate:"time_after=tomorrow"`
+		Days string `validate:"time_weekday=mon sun2"`
+	}{}))
+	require.Error(t, err)
+	for _, s := range []string{"BadWithin.At", "BadClock.At", `"time_after"`, `"time_weekday"`} {
+		require.Contains(t, err.Error(), s)
+	}
+
+	// 默认时区为 UTC, 与服务器时区无关
+	require.NoError(t, def.Struct(&struct {
+		At time.Time `validate:"time_of_day=00:00~00:59,time_weekday=tue"`
+	}{At: time.Date(2024, 1, 2, 0, 30, 0, 0, time.UTC)}))
+}
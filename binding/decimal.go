@@ -0,0 +1,198 @@
+package binding
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// decimalParamCache 已解析的标签参数, param -> decimal.Decimal
+	decimalParamCache sync.Map
+	// decimalRangeParamCache 已解析的范围参数, param -> [2]decimal.Decimal
+	decimalRangeParamCache sync.Map
+	// digitsParamCache 已解析的位数参数, param -> int32
+	digitsParamCache sync.Map
+)
+
+var (
+	decimalType     = reflect.TypeOf(decimal.Decimal{})
+	nullDecimalType = reflect.TypeOf(decimal.NullDecimal{})
+)
+
+// parseDecimalParam 解析标签参数, 解析结果会被缓存.
+// 标签参数的取值是有限的, 调用方传入的任意字符串不能使用此函数, 以免缓存无限增长.
+func parseDecimalParam(param string) (decimal.Decimal, error) {
+	if v, ok := decimalParamCache.Load(param); ok {
+		return v.(decimal.Decimal), nil
+	}
+	d, err := decimal.NewFromString(param)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	decimalParamCache.Store(param, d)
+	return d, nil
+}
+
+// parseDecimalRangeParam 解析范围参数 min~max, 解析结果会被缓存.
+func parseDecimalRangeParam(param string) (min, max decimal.Decimal, err error) {
+	if v, ok := decimalRangeParamCache.Load(param); ok {
+		r := v.([2]decimal.Decimal)
+		return r[0], r[1], nil
+	}
+	minStr, maxStr, ok := strings.Cut(param, "~")
+	if !ok {
+		return min, max, fmt.Errorf("range param %q must be min~max", param)
+	}
+	if min, err = decimal.NewFromString(minStr); err != nil {
+		return min, max, err
+	}
+	if max, err = decimal.NewFromString(maxStr); err != nil {
+		return min, max, err
+	}
+	if min.GreaterThan(max) {
+		return min, max, fmt.Errorf("range param %q min greater than max", param)
+	}
+	decimalRangeParamCache.Store(param, [2]decimal.Decimal{min, max})
+	return min, max, nil
+}
+
+// parseDigitsParam 解析位数参数, 必须为非负整数, 解析结果会被缓存.
+func parseDigitsParam(param string) (int32, error) {
+	if v, ok := digitsParamCache.Load(param); ok {
+		return v.(int32), nil
+	}
+	n, err := strconv.ParseInt(param, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("digits param %q must be not negative", param)
+	}
+	digitsParamCache.Store(param, int32(n))
+	return int32(n), nil
+}
+
+func checkDecimalParam(param string) error {
+	_, err := parseDecimalParam(param)
+	return err
+}
+
+func checkDecimalRangeParam(param string) error {
+	_, _, err := parseDecimalRangeParam(param)
+	return err
+}
+
+func checkDigitsParam(param string) error {
+	_, err := parseDigitsParam(param)
+	return err
+}
+
+func checkDecimalStepParam(param string) error {
+	_, err := parseDecimalStepParam(param)
+	return err
+}
+
+// parseDecimalStepParam 解析步长参数, 必须大于 0.
+func parseDecimalStepParam(param string) (decimal.Decimal, error) {
+	d, err := parseDecimalParam(param)
+	if err != nil {
+		return d, err
+	}
+	if !d.IsPositive() {
+		return d, fmt.Errorf("step param %q must be greater than 0", param)
+	}
+	return d, nil
+}
+
+// decimalScale 有效小数位数, 忽略小数末尾的 0.
+func decimalScale(d decimal.Decimal) int32 {
+	coef, exp := decimalNormalize(d)
+	if exp >= 0 || coef.Sign() == 0 {
+		return 0
+	}
+	return -exp
+}
+
+// decimalPrecision 有效数字总位数(整数位数 + 有效小数位数), 忽略小数末尾的 0, 整数部分为 0 时不计入.
+func decimalPrecision(d decimal.Decimal) int32 {
+	coef, exp := decimalNormalize(d)
+	if coef.Sign() == 0 {
+		return 1
+	}
+	digits := int32(len(coef.Abs(coef).String()))
+	if exp >= 0 {
+		return digits + exp
+	}
+	if digits < -exp { // 如 0.012, 整数部分为 0
+		return -exp
+	}
+	return digits
+}
+
+// decimalNormalize 去除系数末尾的 0
+func decimalNormalize(d decimal.Decimal) (*big.Int, int32) {
+	coef, exp := d.Coefficient(), d.Exponent()
+	ten := big.NewInt(10)
+	mod := new(big.Int)
+	for exp < 0 && coef.Sign() != 0 {
+		q, m := new(big.Int).QuoRem(coef, ten, mod)
+		if m.Sign() != 0 {
+			break
+		}
+		coef, exp = q, exp+1
+	}
+	return coef, exp
+}
+
+// decimalCustomTypeFunc 将 decimal.Decimal, decimal.NullDecimal 转换为字符串,
+// 以使 validator 可以对其使用校验标签, 无效的 decimal.NullDecimal 视为 nil.
+func decimalCustomTypeFunc(field reflect.Value) any {
+	switch v := field.Interface().(type) {
+	case decimal.Decimal:
+		return v.String()
+	case decimal.NullDecimal:
+		if v.Valid {
+			return v.Decimal.String()
+		}
+	}
+	return nil
+}
+
+// decimalFromField 从字段中获取 decimal.
+// 支持 string, decimal.Decimal, decimal.NullDecimal, 整型, 浮点型以及它们的指针.
+// supported 为 false 表示字段类型不支持.
+func decimalFromField(field reflect.Value) (d decimal.Decimal, ok, supported bool) {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return d, false, true
+		}
+		field = field.Elem()
+	}
+	switch field.Type() {
+	case decimalType:
+		return field.Interface().(decimal.Decimal), true, true
+	case nullDecimalType:
+		v := field.Interface().(decimal.NullDecimal)
+		return v.Decimal, v.Valid, true
+	}
+	switch field.Kind() {
+	case reflect.String:
+		d, err := decimal.NewFromString(field.String())
+		return d, err == nil, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decimal.NewFromInt(field.Int()), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(field.Uint()), 0), true, true
+	case reflect.Float32:
+		return decimal.NewFromFloat32(float32(field.Float())), true, true
+	case reflect.Float64:
+		return decimal.NewFromFloat(field.Float()), true, true
+	}
+	return d, false, false
+}
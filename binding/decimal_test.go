@@ -0,0 +1,221 @@
+package binding
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDecimalCompare(t *testing.T) {
+	tests := []struct {
+		s, t                   string
+		gt, gte, lt, lte, want bool
+	}{
+		{"1", "0", true, true, false, false, true},
+		{"0", "0", false, true, false, true, true},
+		{"0.00", "0", false, true, false, true, true},
+		{"-0.01", "0", false, false, true, true, true},
+		{"99.999", "100", false, false, true, true, true},
+		{"abc", "0", false, false, false, false, false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.gt, IsDecimalGt(tt.s, tt.t), tt.s)
+		require.Equal(t, tt.gte, IsDecimalGte(tt.s, tt.t), tt.s)
+		require.Equal(t, tt.lt, IsDecimalLt(tt.s, tt.t), tt.s)
+		require.Equal(t, tt.lte, IsDecimalLte(tt.s, tt.t), tt.s)
+	}
+}
+
+func TestValidDecimal(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type Amount struct {
+		Str     string              `validate:"decimal_gt=0,decimal_lte=100"`
+		Dec     decimal.Decimal     `validate:"decimal_gt=0,decimal_lte=100"`
+		NullDec decimal.NullDecimal `validate:"omitempty,decimal_gt=0"`
+		Float   float64             `validate:"decimal_min=1,decimal_max=100"`
+		IntPtr  *int32              `validate:"omitempty,decimal_gt=0"`
+	}
+	i32 := int32(1)
+	require.NoError(t, valid.Struct(&Amount{
+		Str:     "0.01",
+		Dec:     decimal.RequireFromString("100"),
+		NullDec: decimal.NewNullDecimal(decimal.NewFromInt(1)),
+		Float:   1,
+		IntPtr:  &i32,
+	}))
+	// 空的可选字段
+	require.NoError(t, valid.Struct(&Amount{Str: "1", Dec: decimal.NewFromInt(1), Float: 100}))
+
+	i32 = 0
+	err := valid.Struct(&Amount{
+		Str:     "abc",
+		Dec:     decimal.RequireFromString("100.01"),
+		NullDec: decimal.NewNullDecimal(decimal.Zero),
+		Float:   0.5,
+		IntPtr:  &i32,
+	})
+	require.Equal(t, []string{
+		"Str:decimal_gt",
+		"Dec:decimal_lte",
+		"NullDec:decimal_gt",
+		"Float:decimal_min",
+		"IntPtr:decimal_gt",
+	}, fieldErrorTags(t, err))
+}
+
+func TestValidDecimalBadParam(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type BadParam struct {
+		Amount string `validate:"decimal_gt=abc"`
+	}
+	// 标签参数错误时 panic, 与字段的值无关
+	for _, amount := range []string{"1", "x"} {
+		require.PanicsWithValue(t, `binding: Amount tag "decimal_gt" has invalid param "abc": can't convert abc to decimal`, func() {
+			_ = valid.Struct(&BadParam{Amount: amount})
+		})
+	}
+	type BadStep struct {
+		Amount string `validate:"decimal_multiple_of=0"`
+	}
+	require.Panics(t, func() {
+		_ = valid.Struct(&BadStep{Amount: "1"})
+	})
+	require.False(t, IsDecimalGt("1", "abc"))
+
+	// 调用方传入的参数不会被缓存
+	count := func() (n int) {
+		decimalParamCache.Range(func(_, _ any) bool { n++; return true })
+		return n
+	}
+	n := count()
+	for i := 0; i < 10; i++ {
+		require.True(t, IsDecimalLt("1", strconv.Itoa(i+100)))
+	}
+	require.Equal(t, n, count())
+
+	type BadFieldType struct {
+		Amount bool `validate:"decimal_gt=1"`
+	}
+	require.Panics(t, func() {
+		_ = valid.Struct(&BadFieldType{})
+	})
+}
+
+func TestValidDecimalScaleAndPrecision(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type Price struct {
+		Str   string          `validate:"decimal_scale=2,decimal_precision=6"`
+		Dec   decimal.Decimal `validate:"decimal_scale=2"`
+		Range string          `validate:"decimal_between=0.01~100"`
+		Tick  decimal.Decimal `validate:"decimal_multiple_of=0.05"`
+	}
+	require.NoError(t, valid.Struct(&Price{
+		Str:   "1234.50",
+		Dec:   decimal.RequireFromString("-0.01"),
+		Range: "100",
+		Tick:  decimal.RequireFromString("10.15"),
+	}))
+
+	err := valid.Struct(&Price{
+		Str:   "12345.67",
+		Dec:   decimal.RequireFromString("0.125"),
+		Range: "0.001",
+		Tick:  decimal.RequireFromString("10.12"),
+	})
+	require.Equal(t, []string{
+		"Str:decimal_precision",
+		"Dec:decimal_scale",
+		"Range:decimal_between",
+		"Tick:decimal_multiple_of",
+	}, fieldErrorTags(t, err))
+
+	type BadParam struct {
+		Scale string `validate:"decimal_scale=-1"`
+		Range string `validate:"decimal_between=2~1"`
+		Step  string `validate:"decimal_multiple_of=0"`
+	}
+	require.Panics(t, func() { _ = valid.Struct(&BadParam{"1", "1", "1"}) })
+
+	err = ValidateTags([]any{&BadParam{}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `tag "decimal_scale" has invalid param "-1"`)
+	require.Contains(t, err.Error(), `tag "decimal_between" has invalid param "2~1"`)
+	require.Contains(t, err.Error(), `tag "decimal_multiple_of" has invalid param "0"`)
+}
+
+func TestDecimalScaleAndPrecision(t *testing.T) {
+	tests := []struct {
+		value     string
+		scale     int32
+		precision int32
+	}{
+		{"0", 0, 1},
+		{"0.00", 0, 1},
+		{"1200", 0, 4},
+		{"-12.340", 2, 4},
+		{"0.012", 3, 3},
+		{"1e-5", 5, 5},
+		{"1.5e3", 0, 4},
+	}
+	for _, tt := range tests {
+		d := decimal.RequireFromString(tt.value)
+		require.Equal(t, tt.scale, decimalScale(d), tt.value)
+		require.Equal(t, tt.precision, decimalPrecision(d), tt.value)
+	}
+}
+
+func TestValidDecimalField(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type Limit struct {
+		MinAmount string          `validate:"decimal"`
+		MaxAmount decimal.Decimal `validate:"decimal_gtfield=MinAmount"`
+	}
+	type Order struct {
+		Limit    Limit
+		Price    string           `validate:"decimal_gtecsfield=Limit.MinAmount,decimal_ltecsfield=Limit.MaxAmount"`
+		Discount *decimal.Decimal `validate:"omitempty,decimal_ltefield=Price"`
+		Cost     float64          `validate:"decimal_ltfield=Price"`
+		Paid     string           `validate:"decimal_gtefield=Discount"`
+	}
+	discount := decimal.RequireFromString("10.00")
+	require.NoError(t, valid.Struct(&Order{
+		Limit:    Limit{MinAmount: "1", MaxAmount: decimal.RequireFromString("100")},
+		Price:    "100.00",
+		Discount: &discount,
+		Cost:     99.99,
+		Paid:     "10",
+	}))
+
+	err := valid.Struct(&Order{
+		Limit:    Limit{MinAmount: "100", MaxAmount: decimal.RequireFromString("100")},
+		Price:    "100.01",
+		Discount: &discount,
+		Cost:     100.01,
+		Paid:     "9.99",
+	})
+	require.Equal(t, []string{
+		"MaxAmount:decimal_gtfield",
+		"Price:decimal_ltecsfield",
+		"Cost:decimal_ltfield",
+		"Paid:decimal_gtefield",
+	}, fieldErrorTags(t, err))
+
+	// 另一字段为空或不存在时校验失败
+	err = valid.Struct(&Order{
+		Limit: Limit{MinAmount: "1", MaxAmount: decimal.RequireFromString("100")},
+		Price: "1",
+		Paid:  "1",
+	})
+	require.Equal(t, []string{"Paid:decimal_gtefield"}, fieldErrorTags(t, err))
+
+	type Missing struct {
+		Amount string `validate:"decimal_gtfield=NotExist"`
+	}
+	require.Error(t, valid.Struct(&Missing{Amount: "1"}))
+}
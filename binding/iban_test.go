@@ -0,0 +1,40 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsIBAN(t *testing.T) {
+	for _, s := range []string{
+		"GB82WEST12345698765432",
+		"gb82 west 1234 5698 7654 32",
+		"DE89370400440532013000",
+		"FR1420041010050500013M02606",
+		"NO9386011117947",
+	} {
+		require.True(t, IsIBAN(s), s)
+	}
+	for _, s := range []string{
+		"GB82WEST12345698765431", // 校验码
+		"GB82WEST1234569876543",  // 长度
+		"XX82WEST12345698765432", // 国家
+		"GB8AWEST12345698765432", // 校验位非数字
+		"GB82WEST1234569876543-", // 字符
+		"GB",
+	} {
+		require.False(t, IsIBAN(s), s)
+	}
+	require.Equal(t, "GB82WEST12345698765432", NormalizeIBAN("gb82 west 1234 5698 7654 32"))
+}
+
+func TestIsSwiftBic(t *testing.T) {
+	require.True(t, IsSwiftBic("DEUTDEFF"))
+	require.True(t, IsSwiftBic("DEUTDEFF500"))
+	require.True(t, IsSwiftBic("BKCHCNBJ"))
+	require.False(t, IsSwiftBic("DEUTDEF"))
+	require.False(t, IsSwiftBic("deutdeff"))
+	require.False(t, IsSwiftBic("DEU1DEFF"))
+	require.False(t, IsSwiftBic("DEUTDEFF50"))
+}
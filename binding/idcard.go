@@ -0,0 +1,210 @@
+package binding
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// error defined
+var (
+	ErrIDCardInvalid    = errors.New("idcard: invalid format")
+	ErrIDCardRegion     = errors.New("idcard: invalid region code")
+	ErrIDCardBirthDate  = errors.New("idcard: invalid birth date")
+	ErrIDCardCheckDigit = errors.New("idcard: check digit mismatch")
+	ErrUSCCInvalid      = errors.New("uscc: invalid format")
+	ErrUSCCCheckDigit   = errors.New("uscc: check digit mismatch")
+)
+
+// Gender 性别
+type Gender int
+
+// Gender defined
+const (
+	GenderFemale Gender = iota
+	GenderMale
+)
+
+// String implement fmt.Stringer
+func (g Gender) String() string {
+	if g == GenderMale {
+		return "male"
+	}
+	return "female"
+}
+
+// provinces 省级行政区划代码(GB/T 2260), 81, 82, 83 为港澳台居民居住证.
+var provinces = map[string]string{
+	"11": "北京市", "12": "天津市", "13": "河北省", "14": "山西省", "15": "内蒙古自治区",
+	"21": "辽宁省", "22": "吉林省", "23": "黑龙江省",
+	"31": "上海市", "32": "江苏省", "33": "浙江省", "34": "安徽省", "35": "福建省", "36": "江西省", "37": "山东省",
+	"41": "河南省", "42": "湖北省", "43": "湖南省", "44": "广东省", "45": "广西壮族自治区", "46": "海南省",
+	"50": "重庆市", "51": "四川省", "52": "贵州省", "53": "云南省", "54": "西藏自治区",
+	"61": "陕西省", "62": "甘肃省", "63": "青海省", "64": "宁夏回族自治区", "65": "新疆维吾尔自治区",
+	"71": "台湾省", "81": "香港特别行政区", "82": "澳门特别行政区", "83": "台湾地区",
+}
+
+var (
+	idCardWeights = [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	idCardChecks  = "10X98765432"
+
+	// rxHKMacauPermit 港澳居民来往内地通行证, H(香港) 或 M(澳门) 开头, 8 位或 10 位数字.
+	rxHKMacauPermit = regexp.MustCompile(`^[HM](\d{8}|\d{10})$`)
+	// rxTaiwanPermit 台湾居民来往大陆通行证, 8 位(2015 年起的卡式证件)或 10 位(旧版本式证件)数字.
+	rxTaiwanPermit = regexp.MustCompile(`^(\d{8}|\d{10})$`)
+)
+
+// IDCard 解析后的居民身份证号码
+type IDCard struct {
+	// Number 身份证号码, 校验码为大写
+	Number string
+	// Region 6 位行政区划代码
+	Region string
+	// Province 省级行政区名称
+	Province string
+	// BirthDate 出生日期
+	BirthDate time.Time
+	// Gender 性别
+	Gender Gender
+}
+
+// ParseIDCard 解析 18 位居民身份证号码(含港澳台居民居住证), 校验行政区划代码, 出生日期和校验码.
+// 行政区划代码须在当前的代码表中(见 LoadRegions), 已撤销的县级代码, 只要所属地级仍存在也视为有效,
+// 已撤销的地级下的代码视为无效, 需要时可使用包含历史代码的代码表.
+func ParseIDCard(s string) (*IDCard, error) {
+	if len(s) != 18 || !isDigits(s[:17]) {
+		return nil, ErrIDCardInvalid
+	}
+	s = strings.ToUpper(s)
+	last := s[17]
+	if (last < '0' || last > '9') && last != 'X' {
+		return nil, ErrIDCardInvalid
+	}
+
+	province, ok := provinces[s[:2]]
+	if !ok || !isIDCardRegion(s[:6]) {
+		return nil, ErrIDCardRegion
+	}
+	birth, err := time.Parse("20060102", s[6:14])
+	if err != nil || birth.Year() < 1800 || birth.After(time.Now()) {
+		return nil, ErrIDCardBirthDate
+	}
+	if idCardCheckDigit(s[:17]) != last {
+		return nil, ErrIDCardCheckDigit
+	}
+
+	gender := GenderFemale
+	if (s[16]-'0')%2 == 1 {
+		gender = GenderMale
+	}
+	return &IDCard{
+		Number:    s,
+		Region:    s[:6],
+		Province:  province,
+		BirthDate: birth,
+		Gender:    gender,
+	}, nil
+}
+
+// IsIDCard 是否是 18 位居民身份证号码, 见 ParseIDCard.
+func IsIDCard(s string) bool {
+	_, err := ParseIDCard(s)
+	return err == nil
+}
+
+// IsHKMacauPermit 是否是港澳居民来往内地通行证号码, 如 H12345678.
+func IsHKMacauPermit(s string) bool {
+	return rxHKMacauPermit.MatchString(s)
+}
+
+// IsTaiwanPermit 是否是台湾居民来往大陆通行证号码, 如 12345678.
+// 证件号码没有校验码, 这里只校验格式, 不能确认号码是否真实签发.
+func IsTaiwanPermit(s string) bool {
+	return rxTaiwanPermit.MatchString(s)
+}
+
+// isIDCardRegion 身份证号码的行政区划代码是否有效.
+// 依次查找县级代码和所属地级代码, 地级代码为 01(市辖区), 02(县), 90(省直辖县级行政区划)的,
+// 代码表中没有对应的地级, 查找所属省级.
+func isIDCardRegion(code string) bool {
+	if _, ok := LookupRegion(code); ok {
+		return true
+	}
+	if _, ok := LookupRegion(code[:4] + "00"); ok {
+		return true
+	}
+	switch code[2:4] {
+	case "01", "02", "90":
+		_, ok := LookupRegion(code[:2] + "0000")
+		return ok
+	}
+	// 台湾省, 港澳台居民居住证不在代码表中
+	_, ok := provinces[code[:2]]
+	return ok && code[2:] == "0000"
+}
+
+// idCardCheckDigit ISO 7064 MOD 11-2 校验码
+func idCardCheckDigit(s string) byte {
+	sum := 0
+	for i := 0; i < 17; i++ {
+		sum += int(s[i]-'0') * idCardWeights[i]
+	}
+	return idCardChecks[sum%11]
+}
+
+// usccCharset 统一社会信用代码字符集, 不使用 I, O, Z, S, V.
+const usccCharset = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+
+var usccWeights = [17]int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+
+// USCC 解析后的统一社会信用代码(GB 32100-2015)
+type USCC struct {
+	// Code 统一社会信用代码
+	Code string
+	// Authority 登记管理部门代码, 如 9 为工商
+	Authority byte
+	// OrgType 机构类别代码
+	OrgType byte
+	// Region 6 位登记管理机关行政区划代码
+	Region string
+	// OrgCode 9 位组织机构代码
+	OrgCode string
+}
+
+// ParseUSCC 解析统一社会信用代码, 校验字符集, 行政区划代码和校验码.
+func ParseUSCC(s string) (*USCC, error) {
+	if len(s) != 18 || !isDigits(s[2:8]) {
+		return nil, ErrUSCCInvalid
+	}
+	sum := 0
+	for i := 0; i < 18; i++ {
+		v := strings.IndexByte(usccCharset, s[i])
+		if v < 0 {
+			return nil, ErrUSCCInvalid
+		}
+		if i < 17 {
+			sum += v * usccWeights[i]
+		}
+	}
+	// 100000 为国家级登记管理机关
+	if _, ok := provinces[s[2:4]]; !ok && s[2:8] != "100000" {
+		return nil, ErrUSCCInvalid
+	}
+	if usccCharset[(31-sum%31)%31] != s[17] {
+		return nil, ErrUSCCCheckDigit
+	}
+	return &USCC{
+		Code:      s,
+		Authority: s[0],
+		OrgType:   s[1],
+		Region:    s[2:8],
+		OrgCode:   s[8:17],
+	}, nil
+}
+
+// IsUSCC 是否是统一社会信用代码, 见 ParseUSCC.
+func IsUSCC(s string) bool {
+	_, err := ParseUSCC(s)
+	return err == nil
+}
@@ -0,0 +1,111 @@
+package binding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIDCard(t *testing.T) {
+	c, err := ParseIDCard("11010519491231002x")
+	require.NoError(t, err)
+	require.Equal(t, "11010519491231002X", c.Number)
+	require.Equal(t, "110105", c.Region)
+	require.Equal(t, "北京市", c.Province)
+	require.Equal(t, time.Date(1949, 12, 31, 0, 0, 0, 0, time.UTC), c.BirthDate)
+	require.Equal(t, GenderFemale, c.Gender)
+	require.Equal(t, "female", c.Gender.String())
+
+	c, err = ParseIDCard("440524188001010014")
+	require.NoError(t, err)
+	require.Equal(t, GenderMale, c.Gender)
+	require.Equal(t, "广东省", c.Province)
+
+	// 已撤销的县级代码, 直辖市的县, 省直辖县级行政区划, 港澳台居民居住证
+	for _, s := range []string{"130199199001010014", "110224199001010015", "469099199001010013", "810000199001010019"} {
+		require.True(t, IsIDCard(s), s)
+	}
+
+	tests := []struct {
+		name string
+		s    string
+		err  error
+	}{
+		{"length", "1101051949123100", ErrIDCardInvalid},
+		{"letter", "11010519491231A02X", ErrIDCardInvalid},
+		{"check char", "11010519491231002Y", ErrIDCardInvalid},
+		{"region", "99010519491231002X", ErrIDCardRegion},
+		{"withdrawn city", "342501199001010011", ErrIDCardRegion},
+		{"unknown city", "139901199001010010", ErrIDCardRegion},
+		{"birth date", "110105194913310029", ErrIDCardBirthDate},
+		{"future", "110105299901010020", ErrIDCardBirthDate},
+		{"check digit", "110105194912310021", ErrIDCardCheckDigit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseIDCard(tt.s)
+			require.ErrorIs(t, err, tt.err)
+			require.False(t, IsIDCard(tt.s))
+		})
+	}
+}
+
+func TestPermit(t *testing.T) {
+	require.True(t, IsHKMacauPermit("H12345678"))
+	require.True(t, IsHKMacauPermit("M1234567890"))
+	require.False(t, IsHKMacauPermit("A12345678"))
+	require.False(t, IsHKMacauPermit("H1234567"))
+
+	require.True(t, IsTaiwanPermit("12345678"))
+	require.True(t, IsTaiwanPermit("1234567890"))
+	require.False(t, IsTaiwanPermit("123456789"))
+	require.False(t, IsTaiwanPermit("T12345678"))
+}
+
+func TestParseUSCC(t *testing.T) {
+	u, err := ParseUSCC("91350100M000100Y43")
+	require.NoError(t, err)
+	require.Equal(t, byte('9'), u.Authority)
+	require.Equal(t, byte('1'), u.OrgType)
+	require.Equal(t, "350100", u.Region)
+	require.Equal(t, "M000100Y4", u.OrgCode)
+
+	_, err = ParseUSCC("91350100M000100Y44")
+	require.ErrorIs(t, err, ErrUSCCCheckDigit)
+	_, err = ParseUSCC("91350100M000100YI3")
+	require.ErrorIs(t, err, ErrUSCCInvalid)
+	_, err = ParseUSCC("9135010")
+	require.ErrorIs(t, err, ErrUSCCInvalid)
+	require.False(t, IsUSCC("919901000000000000"))
+}
+
+func TestValidIdentity(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type KYC struct {
+		IDCard   string `validate:"omitempty,idcard"`
+		HKMacau  string `validate:"omitempty,hk_macau_permit"`
+		Taiwan   string `validate:"omitempty,taiwan_permit"`
+		Business string `validate:"omitempty,uscc"`
+	}
+	require.NoError(t, valid.Struct(&KYC{
+		IDCard:   "11010519491231002X",
+		HKMacau:  "H12345678",
+		Taiwan:   "12345678",
+		Business: "91350100M000100Y43",
+	}))
+
+	err := valid.Struct(&KYC{
+		IDCard:   "110105194912310021",
+		HKMacau:  "X12345678",
+		Taiwan:   "1234",
+		Business: "91350100M000100Y44",
+	})
+	require.Equal(t, []string{
+		"IDCard:idcard",
+		"HKMacau:hk_macau_permit",
+		"Taiwan:taiwan_permit",
+		"Business:uscc",
+	}, fieldErrorTags(t, err))
+}
@@ -0,0 +1,161 @@
+// regiongen 根据民政部发布的县以上行政区划代码生成 region.txt.
+//
+// 用法:
+//
+//	go run ./internal/regiongen -o region.txt -version 2022 <url 或文件>
+//
+// 输入为民政部网站的行政区划代码页面(html)或从中复制的文本, 依次出现的 "6 位代码 名称" 被视为一条记录.
+// 版本和输入必须指定, 记录数少于 -min 时视为输入错误, 出错时不会修改输出文件.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	rxTag     = regexp.MustCompile(`<[^>]*>`)
+	rxCode    = regexp.MustCompile(`^\d{6}$`)
+	rxVersion = regexp.MustCompile(`^\d{4}$`)
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("regiongen", flag.ContinueOnError)
+	output := fs.String("o", "", "输出文件, 默认为标准输出")
+	version := fs.String("version", "", "代码表版本, 写入文件头, 如 2022")
+	min := fs.Int("min", 3000, "最少的记录数")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || fs.Arg(0) == "" {
+		return fmt.Errorf("regiongen: source is required (GB2260_SOURCE), usage: regiongen -version 2022 [-o region.txt] <url or file>")
+	}
+	if !rxVersion.MatchString(*version) {
+		return fmt.Errorf("regiongen: version %q must be a year (GB2260_VERSION), such as 2022", *version)
+	}
+
+	data, err := read(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	regions, err := parse(data)
+	if err != nil {
+		return err
+	}
+	if len(regions) < *min {
+		return fmt.Errorf("regiongen: only %d regions found, at least %d expected", len(regions), *min)
+	}
+	var b bytes.Buffer
+	write(&b, regions, *version)
+	if *output == "" {
+		_, err = os.Stdout.Write(b.Bytes())
+		return err
+	}
+	return writeFile(*output, b.Bytes())
+}
+
+// writeFile 先写入临时文件再重命名, 避免写入失败时破坏原文件.
+func writeFile(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // nolint: errcheck
+	if _, err = f.Write(data); err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(f.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+func read(src string) ([]byte, error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		return os.ReadFile(src)
+	}
+	resp, err := http.Get(src) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("regiongen: %s: %s", src, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parse 去除 html 标签后, 提取相邻的 "代码 名称", 并检查每个县级, 地级的所属省级都存在.
+func parse(data []byte) (map[string]string, error) {
+	text := html.UnescapeString(rxTag.ReplaceAllString(string(data), "\n"))
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '\u200b' || r == '\ufeff'
+	})
+
+	regions := make(map[string]string)
+	for i := 0; i+1 < len(fields); i++ {
+		code, name := fields[i], fields[i+1]
+		if !rxCode.MatchString(code) || code[:2] == "00" || strings.IndexFunc(name, unicode.IsDigit) >= 0 {
+			continue
+		}
+		// 名称中可能带有标注, 如 "*", 去除
+		name = strings.TrimRight(name, "*＊")
+		if prev, ok := regions[code]; ok && prev != name {
+			return nil, fmt.Errorf("regiongen: duplicate code %s: %s, %s", code, prev, name)
+		}
+		regions[code] = name
+		i++
+	}
+	if len(regions) == 0 {
+		return nil, fmt.Errorf("regiongen: no region found")
+	}
+	for code := range regions {
+		if _, ok := regions[code[:2]+"0000"]; !ok {
+			return nil, fmt.Errorf("regiongen: province of %s not found", code)
+		}
+	}
+	return regions, nil
+}
+
+func write(w io.Writer, regions map[string]string, version string) {
+	codes := make([]string, 0, len(regions))
+	for code := range regions {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	bw := bufio.NewWriter(w)
+	title := "中华人民共和国县以上行政区划代码(GB/T 2260)"
+	if version != "" {
+		title = version + "年" + title
+	}
+	fmt.Fprintf(bw, "# %s\n", title)
+	fmt.Fprintln(bw, "# 每行为 \"代码 名称\", 以 # 开头的行为注释.")
+	fmt.Fprintln(bw, "# Code generated by internal/regiongen from the Ministry of Civil Affairs publication. DO NOT EDIT.")
+	for _, code := range codes {
+		fmt.Fprintf(bw, "%s %s\n", code, regions[code])
+	}
+	bw.Flush() // nolint: errcheck
+}
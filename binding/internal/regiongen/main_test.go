@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	page := `<table>
+<tr><td>行政区划代码</td><td>单位名称</td></tr>
+<tr><td class="xl">130000</td><td>河北省</td></tr>
+<tr><td>130100</td><td>&nbsp;石家庄市</td></tr>
+<tr><td>130102</td><td><span>&#8203;</span>长安区</td></tr>
+</table>`
+	regions, err := parse([]byte(page))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"130000": "河北省", "130100": "石家庄市", "130102": "长安区"}, regions)
+
+	var b bytes.Buffer
+	write(&b, regions, "2022")
+	require.Contains(t, b.String(), "# 2022年中华人民共和国县以上行政区划代码(GB/T 2260)\n")
+	require.Contains(t, b.String(), "130000 河北省\n130100 石家庄市\n130102 长安区\n")
+
+	_, err = parse([]byte(`<td>130102</td><td>长安区</td>`))
+	require.Error(t, err)
+	_, err = parse([]byte(`<td>130000</td><td>河北省</td><td>130000</td><td>山西省</td>`))
+	require.Error(t, err)
+	_, err = parse([]byte(`no region`))
+	require.Error(t, err)
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.html")
+	out := filepath.Join(dir, "region.txt")
+	require.NoError(t, os.WriteFile(src, []byte(`<td>130000</td><td>河北省</td><td>130100</td><td>石家庄市</td>`), 0o644))
+	require.NoError(t, os.WriteFile(out, []byte("old"), 0o644))
+
+	for _, args := range [][]string{
+		{"-version=", "-o", out, src},
+		{"-version=2022", "-o", out, ""},
+		{"-version=2022", "-o", out},
+		{"-version=22", "-o", out, src},
+		{"-version=2022", "-o", out, src},
+		{"-version=2022", "-o", out, filepath.Join(dir, "missing.html")},
+	} {
+		require.Error(t, run(args), args)
+	}
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "old", string(b))
+
+	require.NoError(t, run([]string{"-version=2022", "-min=2", "-o", out, src}))
+	b, err = os.ReadFile(out)
+	require.NoError(t, err)
+	require.Contains(t, string(b), "130100 石家庄市\n")
+}
@@ -0,0 +1,194 @@
+package binding
+
+import (
+	"encoding"
+	"fmt"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	fileHeaderType      = reflect.TypeOf((*multipart.FileHeader)(nil))
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// mapFields 遍历结构体中带有 tag 标签的字段, 根据 lookup 查到的值设置字段.
+// 匿名结构体和未带有 tag 标签的结构体字段会被递归遍历.
+func mapFields(v reflect.Value, tag string, lookup func(name string) ([]string, bool)) error {
+	_, err := walkFields(v, tag, func(field reflect.Value, sf reflect.StructField, tagValue string) (bool, error) {
+		if isFileField(field.Type()) {
+			return false, nil
+		}
+		name, _, _ := strings.Cut(tagValue, ",")
+		values, ok := lookup(name)
+		if !ok || len(values) == 0 {
+			return false, nil
+		}
+		if err := setField(field, values); err != nil {
+			return false, &BindError{Source: tag, Field: sf.Name, Err: err}
+		}
+		return true, nil
+	})
+	return err
+}
+
+// walkFields 遍历结构体中带有 tag 标签的字段, fn 的参数为字段值, 字段和标签值, 返回是否设置了字段.
+// 为 nil 的匿名结构体指针只在其中有字段被设置时才分配.
+func walkFields(v reflect.Value, tag string, fn func(field reflect.Value, sf reflect.StructField, tagValue string) (bool, error)) (bool, error) {
+	var set bool
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := v.Field(i)
+		tagValue := sf.Tag.Get(tag)
+		if tagValue == "-" {
+			continue
+		}
+		if tagValue == "" {
+			if sf.Anonymous && sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Struct {
+				if !field.CanSet() {
+					continue
+				}
+				if field.IsNil() {
+					ptr := reflect.New(sf.Type.Elem())
+					ok, err := walkFields(ptr.Elem(), tag, fn)
+					if err != nil {
+						return set, err
+					}
+					if ok {
+						field.Set(ptr)
+						set = true
+					}
+					continue
+				}
+				field = field.Elem()
+			}
+			if field.Kind() == reflect.Struct && (sf.Anonymous || sf.IsExported()) && !isScalarStruct(field.Type()) {
+				ok, err := walkFields(field, tag, fn)
+				if err != nil {
+					return set, err
+				}
+				set = set || ok
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		ok, err := fn(field, sf, tagValue)
+		if err != nil {
+			return set, err
+		}
+		set = set || ok
+	}
+	return set, nil
+}
+
+// isFileField 是否为 multipart 文件字段
+func isFileField(t reflect.Type) bool {
+	return t == fileHeaderType || (t.Kind() == reflect.Slice && t.Elem() == fileHeaderType)
+}
+
+// isScalarStruct 作为单个值处理的结构体, 如 time.Time, decimal.Decimal.
+func isScalarStruct(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// setField 使用字符串值设置字段, 切片使用全部值, 其它类型使用第一个值.
+func setField(field reflect.Value, values []string) error {
+	if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, s := range values {
+			if err := setValue(slice.Index(i), s); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return setValue(field, values[0])
+}
+
+func setValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setValue(v.Elem(), s)
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		if s == "" {
+			v.SetBool(false)
+			return nil
+		}
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		v.SetBytes([]byte(s))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// setDefaults 为零值字段设置 default 标签中的默认值, 切片的默认值以 ',' 分隔.
+func setDefaults(v reflect.Value) error {
+	_, err := walkFields(v, "default", func(field reflect.Value, sf reflect.StructField, tagValue string) (bool, error) {
+		if !field.IsZero() {
+			return false, nil
+		}
+		values := []string{tagValue}
+		if field.Kind() == reflect.Slice {
+			values = strings.Split(tagValue, ",")
+		}
+		if err := setField(field, values); err != nil {
+			return false, &BindError{Source: "default", Field: sf.Name, Err: err}
+		}
+		return true, nil
+	})
+	return err
+}
@@ -0,0 +1,86 @@
+package binding
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFields(t *testing.T) {
+	type Inner struct {
+		Level uint8 `query:"level"`
+	}
+	type Filter struct {
+		*Inner
+		Nested  Inner
+		Ignored string           `query:"-"`
+		Name    string           `query:"name"`
+		Enabled bool             `query:"enabled"`
+		Ratio   float32          `query:"ratio"`
+		Ids     []int64          `query:"id"`
+		Since   time.Time        `query:"since"`
+		Timeout time.Duration    `query:"timeout"`
+		Price   *decimal.Decimal `query:"price"`
+		Raw     []byte           `query:"raw"`
+	}
+	values := map[string][]string{
+		"level":   {"3"},
+		"name":    {"clip"},
+		"enabled": {"true"},
+		"ratio":   {"0.5"},
+		"id":      {"1", "2"},
+		"since":   {"2023-01-02T03:04:05Z"},
+		"timeout": {"1m30s"},
+		"price":   {"1.25"},
+		"raw":     {"bytes"},
+		"-":       {"x"},
+	}
+	var f Filter
+	err := mapFields(reflect.ValueOf(&f).Elem(), "query", func(name string) ([]string, bool) {
+		v, ok := values[name]
+		return v, ok
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint8(3), f.Inner.Level)
+	require.Equal(t, uint8(3), f.Nested.Level)
+	require.Empty(t, f.Ignored)
+	require.Equal(t, "clip", f.Name)
+	require.True(t, f.Enabled)
+	require.Equal(t, float32(0.5), f.Ratio)
+	require.Equal(t, []int64{1, 2}, f.Ids)
+	require.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), f.Since)
+	require.Equal(t, 90*time.Second, f.Timeout)
+	require.Equal(t, "1.25", f.Price.String())
+	require.Equal(t, []byte("bytes"), f.Raw)
+
+	values["level"] = []string{"256"}
+	err = mapFields(reflect.ValueOf(&f).Elem(), "query", func(name string) ([]string, bool) {
+		v, ok := values[name]
+		return v, ok
+	})
+	require.Error(t, err)
+
+	// 没有字段被设置时, 不分配匿名结构体指针
+	f = Filter{}
+	err = mapFields(reflect.ValueOf(&f).Elem(), "query", func(name string) ([]string, bool) {
+		return []string{"clip"}, name == "name"
+	})
+	require.NoError(t, err)
+	require.Nil(t, f.Inner)
+	require.Equal(t, "clip", f.Name)
+}
+
+func TestSetDefaults(t *testing.T) {
+	type Options struct {
+		Size  int      `default:"10"`
+		Sort  string   `default:"id,desc"`
+		Kinds []string `default:"a,b"`
+		Set   int      `default:"10"`
+	}
+	o := Options{Set: 1}
+	require.NoError(t, setDefaults(reflect.ValueOf(&o).Elem()))
+	require.Equal(t, Options{Size: 10, Sort: "id,desc", Kinds: []string{"a", "b"}, Set: 1}, o)
+}
@@ -0,0 +1,230 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrUnknownModifier 未注册的修饰器
+var ErrUnknownModifier = errors.New("modifier: unknown modifier")
+
+// ModifierFunc 修饰函数, param 为标签参数, 如 mod:"decimal=2" 中的 2.
+type ModifierFunc func(s, param string) (string, error)
+
+// Modifier 根据 mod 标签在校验前修饰字段, 如 mod:"trim,half_width,phone".
+// 修饰器按标签中的顺序依次执行, 支持 string, *string, []string 以及 decimal.Decimal 字段,
+// 结构体, 结构体指针和结构体切片字段会被递归处理.
+//
+// 内置修饰器:
+//   - trim: 去除首尾空白
+//   - lower, upper: 转为小写, 大写
+//   - half_width: 全角字符转为半角
+//   - phone=CN: 规范电话号码, 属于参数地区(默认 CN)时为国内格式, 否则为 E.164 格式, 无法解析时保持不变
+//   - e164=CN: 规范电话号码为 E.164 格式, 参数为默认地区(默认 CN), 无法解析时保持不变
+//   - decimal=2: 去除千分位分隔符, 带参数时四舍五入到指定小数位数, 无法解析时保持不变
+//   - html_escape: html 转义
+type Modifier struct {
+	mu  sync.RWMutex
+	fns map[string]ModifierFunc
+}
+
+// NewModifier 新建带有内置修饰器的 Modifier
+func NewModifier() *Modifier {
+	return &Modifier{
+		fns: map[string]ModifierFunc{
+			"trim":        func(s, _ string) (string, error) { return strings.TrimSpace(s), nil },
+			"lower":       func(s, _ string) (string, error) { return strings.ToLower(s), nil },
+			"upper":       func(s, _ string) (string, error) { return strings.ToUpper(s), nil },
+			"half_width":  func(s, _ string) (string, error) { return ToHalfWidth(s), nil },
+			"phone":       modifyPhone,
+			"e164":        modifyE164,
+			"decimal":     modifyDecimal,
+			"html_escape": func(s, _ string) (string, error) { return html.EscapeString(s), nil },
+		},
+	}
+}
+
+// Register 注册自定义修饰器, 已存在则覆盖.
+func (m *Modifier) Register(name string, fn ModifierFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fns[name] = fn
+}
+
+var defaultModifier = NewModifier()
+
+// RegisterModifier 向默认 Modifier 注册自定义修饰器, Bind 使用默认 Modifier.
+func RegisterModifier(name string, fn ModifierFunc) {
+	defaultModifier.Register(name, fn)
+}
+
+// Modify 使用默认 Modifier 修饰 v, v 必须为结构体指针.
+func Modify(v any) error {
+	return defaultModifier.Struct(v)
+}
+
+// Struct 修饰 v, v 必须为结构体指针.
+func (m *Modifier) Struct(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrBindTarget
+	}
+	return m.modifyStruct(rv.Elem())
+}
+
+func (m *Modifier) modifyStruct(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		field := v.Field(i)
+		if tag := sf.Tag.Get("mod"); tag != "" && tag != "-" && sf.IsExported() {
+			if err := m.modifyField(field, tag); err != nil {
+				return fmt.Errorf("modifier: field %q: %w", sf.Name, err)
+			}
+			continue
+		}
+		if err := m.walk(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walk 递归处理未带有 mod 标签的结构体字段
+func (m *Modifier) walk(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return m.walk(v.Elem())
+		}
+	case reflect.Struct:
+		if v.CanSet() && !isScalarStruct(v.Type()) {
+			return m.modifyStruct(v)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := m.walk(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Modifier) modifyField(field reflect.Value, tag string) error {
+	switch {
+	case field.Kind() == reflect.Ptr:
+		if field.IsNil() {
+			return nil
+		}
+		return m.modifyField(field.Elem(), tag)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8:
+		for i := 0; i < field.Len(); i++ {
+			if err := m.modifyField(field.Index(i), tag); err != nil {
+				return err
+			}
+		}
+		return nil
+	case field.Kind() == reflect.String:
+		s, err := m.apply(field.String(), tag)
+		if err != nil {
+			return err
+		}
+		field.SetString(s)
+		return nil
+	case field.Type() == decimalType:
+		s, err := m.apply(field.Interface().(decimal.Decimal).String(), tag)
+		if err != nil {
+			return err
+		}
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(d))
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+}
+
+func (m *Modifier) apply(s, tag string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, mod := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(mod), "=")
+		fn, ok := m.fns[name]
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrUnknownModifier, name)
+		}
+		var err error
+		if s, err = fn(s, param); err != nil {
+			return "", err
+		}
+	}
+	return s, nil
+}
+
+// ToHalfWidth 全角字符转为半角, 如 "１２３ＡＢＣ，" => "123ABC,", 全角空格转为半角空格.
+func ToHalfWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '　':
+			return ' '
+		case r >= '！' && r <= '～':
+			return r - 0xFEE0
+		default:
+			return r
+		}
+	}, s)
+}
+
+func modifyPhone(s, region string) (string, error) {
+	if region == "" {
+		region = "CN"
+	}
+	p, err := ParsePhone(s, region)
+	if err != nil {
+		return s, nil
+	}
+	if p.Region == strings.ToUpper(region) {
+		return p.NationalFormat(), nil
+	}
+	return p.E164(), nil
+}
+
+func modifyE164(s, region string) (string, error) {
+	if region == "" {
+		region = "CN"
+	}
+	p, err := ParsePhone(s, region)
+	if err != nil {
+		return s, nil
+	}
+	return p.E164(), nil
+}
+
+func modifyDecimal(s, scale string) (string, error) {
+	cleaned := strings.NewReplacer(",", "", " ", "", "_", "").Replace(s)
+	d, err := decimal.NewFromString(cleaned)
+	if err != nil {
+		return s, nil
+	}
+	if scale == "" {
+		return cleaned, nil
+	}
+	n, err := parseDigitsParam(scale)
+	if err != nil {
+		return "", fmt.Errorf("decimal modifier has invalid scale %q", scale)
+	}
+	return d.StringFixed(n), nil
+}
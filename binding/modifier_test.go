@@ -0,0 +1,116 @@
+package binding
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifier(t *testing.T) {
+	type Address struct {
+		City string `mod:"trim,half_width"`
+	}
+	type Form struct {
+		Name      string           `mod:"trim,lower"`
+		Code      *string          `mod:"trim,upper"`
+		Mobile    string           `mod:"half_width,phone"`
+		Landline  string           `mod:"phone"`
+		HKMobile  string           `mod:"phone"`
+		Intl      string           `mod:"e164"`
+		Invalid   string           `mod:"phone"`
+		Amount    string           `mod:"half_width,decimal=2"`
+		Plain     string           `mod:"decimal"`
+		Price     decimal.Decimal  `mod:"decimal=1"`
+		PricePtr  *decimal.Decimal `mod:"decimal=0"`
+		Comment   string           `mod:"html_escape"`
+		Tags      []string         `mod:"trim"`
+		Address   Address
+		Addresses []*Address
+		Skip      string `mod:"-"`
+	}
+	code := " abc "
+	price := decimal.RequireFromString("2.5")
+	f := Form{
+		Name:      "  Clip ",
+		Code:      &code,
+		Mobile:    "＋８６ １３８ ００１３ ８０００",
+		Landline:  "+86 10 1234 5678",
+		HKMobile:  "+852 6123 4567",
+		Intl:      "138-0013-8000",
+		Invalid:   "12345",
+		Amount:    "１,２３４.５６７",
+		Plain:     "1_000_000",
+		Price:     decimal.RequireFromString("1.25"),
+		PricePtr:  &price,
+		Comment:   "<b>&</b>",
+		Tags:      []string{" a ", "b "},
+		Address:   Address{City: "　北京　"},
+		Addresses: []*Address{{City: " ＳＨ "}, nil},
+		Skip:      " x ",
+	}
+	require.NoError(t, Modify(&f))
+	require.Equal(t, "clip", f.Name)
+	require.Equal(t, "ABC", *f.Code)
+	require.Equal(t, "13800138000", f.Mobile)
+	require.Equal(t, "01012345678", f.Landline)
+	require.Equal(t, "+85261234567", f.HKMobile)
+	require.Equal(t, "+8613800138000", f.Intl)
+	require.Equal(t, "12345", f.Invalid)
+	require.Equal(t, "1234.57", f.Amount)
+	require.Equal(t, "1000000", f.Plain)
+	require.Equal(t, "1.3", f.Price.String())
+	require.Equal(t, "3", f.PricePtr.String())
+	require.Equal(t, "&lt;b&gt;&amp;&lt;/b&gt;", f.Comment)
+	require.Equal(t, []string{"a", "b"}, f.Tags)
+	require.Equal(t, "北京", f.Address.City)
+	require.Equal(t, "SH", f.Addresses[0].City)
+	require.Equal(t, " x ", f.Skip)
+}
+
+func TestModifierError(t *testing.T) {
+	m := NewModifier()
+	require.ErrorIs(t, m.Struct(struct{}{}), ErrBindTarget)
+
+	type Unknown struct {
+		Name string `mod:"trim,unknown"`
+	}
+	require.ErrorIs(t, m.Struct(&Unknown{}), ErrUnknownModifier)
+
+	type BadType struct {
+		Count int `mod:"trim"`
+	}
+	require.Error(t, m.Struct(&BadType{}))
+
+	type BadScale struct {
+		Amount string `mod:"decimal=x"`
+	}
+	require.Error(t, m.Struct(&BadScale{Amount: "1"}))
+
+	m.Register("unknown", func(s, param string) (string, error) { return s + param, nil })
+	u := Unknown{Name: " a "}
+	require.NoError(t, m.Struct(&u))
+	require.Equal(t, "a", u.Name)
+}
+
+func TestBindModify(t *testing.T) {
+	RegisterModifier("strip_dash", func(s, _ string) (string, error) { return strings.ReplaceAll(s, "-", ""), nil })
+
+	type Register struct {
+		Mobile string `query:"mobile" mod:"phone" validate:"mobile"`
+		Amount string `query:"amount" mod:"decimal=2" validate:"decimal_gt=1000"`
+		Code   string `query:"code" mod:"strip_dash" validate:"len=6"`
+	}
+	r := httptest.NewRequest(http.MethodGet, "/?mobile=%2B86+138+0013+8000&amount=1,234.5&code=12-34-56", nil)
+	var reg Register
+	require.NoError(t, Bind(r, &reg))
+	require.Equal(t, Register{Mobile: "13800138000", Amount: "1234.50", Code: "123456"}, reg)
+}
+
+func TestToHalfWidth(t *testing.T) {
+	require.Equal(t, "123ABC, !~", ToHalfWidth("１２３ＡＢＣ，　！～"))
+	require.Equal(t, "中文abc", ToHalfWidth("中文abc"))
+}
@@ -0,0 +1,113 @@
+package binding
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// rxInteger 整数字符串, 可以超出 int64 范围
+var rxInteger = regexp.MustCompile(`^-?\d+$`)
+
+// numberRangeParamCache 已解析的整数范围参数, param -> [2]*big.Int
+var numberRangeParamCache sync.Map
+
+// parseNumberRangeParam 解析标签的整数范围参数 min~max, 解析结果会被缓存, 返回值不可修改.
+func parseNumberRangeParam(param string) (min, max *big.Int, err error) {
+	if v, ok := numberRangeParamCache.Load(param); ok {
+		r := v.([2]*big.Int)
+		return r[0], r[1], nil
+	}
+	if min, max, err = parseNumberRange(param); err != nil {
+		return nil, nil, err
+	}
+	numberRangeParamCache.Store(param, [2]*big.Int{min, max})
+	return min, max, nil
+}
+
+// parseNumberRange 解析整数范围 min~max
+func parseNumberRange(param string) (min, max *big.Int, err error) {
+	minStr, maxStr, ok := strings.Cut(param, "~")
+	if !ok {
+		return nil, nil, fmt.Errorf("range param %q must be min~max", param)
+	}
+	min, ok = parseInteger(minStr)
+	if !ok {
+		return nil, nil, fmt.Errorf("range param %q has invalid min", param)
+	}
+	max, ok = parseInteger(maxStr)
+	if !ok {
+		return nil, nil, fmt.Errorf("range param %q has invalid max", param)
+	}
+	if min.Cmp(max) > 0 {
+		return nil, nil, fmt.Errorf("range param %q min greater than max", param)
+	}
+	return min, max, nil
+}
+
+func checkNumberRangeParam(param string) error {
+	_, _, err := parseNumberRangeParam(param)
+	return err
+}
+
+// parseInteger 解析整数字符串, 不限制范围.
+func parseInteger(s string) (*big.Int, bool) {
+	if !rxInteger.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Int).SetString(s, 10)
+}
+
+// IsNumberRange 是否是整数字符串且在闭区间 r(min~max) 内, 可以超出 int64 范围, 如 snowflake id.
+func IsNumberRange(s, r string) bool {
+	n, ok := parseInteger(s)
+	if !ok {
+		return false
+	}
+	min, max, err := parseNumberRange(r)
+	if err != nil {
+		return false
+	}
+	return n.Cmp(min) >= 0 && n.Cmp(max) <= 0
+}
+
+// numberFromField 获取字段的整数值, 支持整型, 无符号整型, 字符串(包括 json.Number)以及它们的指针.
+// 字符串需要满足 valid, ok 表示是否是有效的整数, supported 表示是否是支持的字段类型.
+func numberFromField(field reflect.Value, valid func(string) bool) (n *big.Int, ok, supported bool) {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, false, true
+		}
+		field = field.Elem()
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(field.Int()), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(field.Uint()), true, true
+	case reflect.String:
+		s := field.String()
+		if !valid(s) {
+			return nil, false, true
+		}
+		n, ok = parseInteger(s)
+		return n, ok, true
+	default:
+		return nil, false, false
+	}
+}
+
+// fieldNumber 获取字段的整数值, 不支持的字段类型 panic.
+func fieldNumber(fl validator.FieldLevel, valid func(string) bool) (*big.Int, bool) {
+	field := fl.Field()
+	n, ok, supported := numberFromField(field, valid)
+	if !supported {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+	return n, ok
+}
@@ -0,0 +1,118 @@
+package binding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNumberRange(t *testing.T) {
+	tests := []struct {
+		s    string
+		r    string
+		want bool
+	}{
+		{"1", "1~10", true},
+		{"10", "1~10", true},
+		{"0", "1~10", false},
+		{"11", "1~10", false},
+		{"-5", "-10~-1", true},
+		{"1.5", "1~10", false},
+		{"", "1~10", false},
+		{"+1", "1~10", false},
+		{"1846875474390044672", "1~18446744073709551615", true},
+		{"18446744073709551616", "1~18446744073709551615", false},
+		{"99999999999999999999999999", "0~100000000000000000000000000", true},
+		{"1", "10~1", false},
+		{"1", "1-10", false},
+		{"1", "a~10", false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, IsNumberRange(tt.s, tt.r), "%s %s", tt.s, tt.r)
+	}
+
+	// 调用方传入的范围不会被缓存
+	_, cached := numberRangeParamCache.Load("1~10")
+	require.False(t, cached)
+}
+
+func TestParseNumberRangeParam(t *testing.T) {
+	min, max, err := parseNumberRangeParam("-1~18446744073709551616")
+	require.NoError(t, err)
+	require.Equal(t, "-1", min.String())
+	require.Equal(t, "18446744073709551616", max.String())
+
+	// 缓存
+	min2, _, err := parseNumberRangeParam("-1~18446744073709551616")
+	require.NoError(t, err)
+	require.Same(t, min, min2)
+
+	for _, param := range []string{"", "1", "1~", "~1", "1.0~2", "2~1"} {
+		_, _, err = parseNumberRangeParam(param)
+		require.Error(t, err, param)
+	}
+}
+
+func TestValidNumber(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type Order struct {
+		ID       string      `validate:"number_range=1~18446744073709551615"`
+		Quantity int         `validate:"number_gt0"`
+		Stock    *uint32     `validate:"number_gte0"`
+		UserID   json.Number `validate:"number_gt0"`
+		Offset   *int64      `validate:"omitempty,number_gte0"`
+		Page     int8        `validate:"number_range=1~100"`
+		Version  uint64      `validate:"number_range=1~18446744073709551615"`
+	}
+	stock := uint32(0)
+	offset := int64(10)
+	require.NoError(t, valid.Struct(&Order{
+		ID:       "18446744073709551615",
+		Quantity: 1,
+		Stock:    &stock,
+		UserID:   "1846875474390044672",
+		Offset:   &offset,
+		Page:     100,
+		Version:  18446744073709551615,
+	}))
+
+	offset = -1
+	err := valid.Struct(&Order{
+		ID:       "18446744073709551616",
+		Quantity: 0,
+		Stock:    nil,
+		UserID:   "1.5",
+		Offset:   &offset,
+		Page:     -1,
+		Version:  0,
+	})
+	require.Equal(t, []string{
+		"ID:number_range",
+		"Quantity:number_gt0",
+		"Stock:number_gte0",
+		"UserID:number_gt0",
+		"Offset:number_gte0",
+		"Page:number_range",
+		"Version:number_range",
+	}, fieldErrorTags(t, err))
+
+	require.Panics(t, func() {
+		_ = valid.Struct(&struct {
+			Price float64 `validate:"number_gt0"`
+		}{Price: 1})
+	})
+	type BadRange struct {
+		ID string `validate:"number_range=10~1"`
+	}
+	err = RegisterValidation(validator.New(), WithRequestStructs(&BadRange{}))
+	require.EqualError(t, err, `validator: register validation failed, binding: BadRange.ID tag "number_range" has invalid param "10~1": range param "10~1" min greater than max`)
+	for _, param := range []string{"1", "a~1", "1~b", "1.5~2"} {
+		require.Error(t, checkNumberRangeParam(param), param)
+	}
+	require.PanicsWithValue(t, `binding: ID tag "number_range" has invalid param "10~1": range param "10~1" min greater than max`, func() {
+		_ = valid.Struct(&BadRange{ID: "5"})
+	})
+}
@@ -0,0 +1,601 @@
+package binding
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// openAPIRefPrefix OpenAPI 组件 schema 的引用前缀
+const openAPIRefPrefix = "#/components/schemas/"
+
+// rxComponentName OpenAPI 组件名中不允许的字符
+var rxComponentName = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// clipPatterns clip 校验标签对应的 pattern, 比校验函数宽松, 仅用于文档.
+var clipPatterns = map[string]string{
+	"mobile":           `^1\d{10}$`,
+	"e164":             `^\+[1-9]\d{1,14}$`,
+	"idcard":           `^\d{17}[\dXx]$`,
+	"hk_macau_permit":  rxHKMacauPermit.String(),
+	"taiwan_permit":    rxTaiwanPermit.String(),
+	"uscc":             `^[0-9A-HJ-NPQRTUWXY]{2}\d{6}[0-9A-HJ-NPQRTUWXY]{10}$`,
+	"bank_card":        `^\d{13,19}$`,
+	"bank_card_bin":    `^\d{16,19}$`,
+	"swift_bic":        rxSwiftBic.String(),
+	"cn_postal_code":   rxCNPostalCode.String(),
+	"license_plate":    "(" + rxLicensePlate.String() + ")|(" + rxNewEnergyPlate.String() + ")",
+	"new_energy_plate": rxNewEnergyPlate.String(),
+	"region_code":      `^\d{6}$`,
+	"decimal":          `^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`,
+	"number_gt0":       numberGt0RegexString,
+	"number_gte0":      numberGte0RegexString,
+	"number_range":     rxInteger.String(),
+}
+
+// patternRegexps validator 标签对应的 pattern
+var patternRegexps = map[string]string{
+	"alpha":    `^[a-zA-Z]+$`,
+	"alphanum": `^[a-zA-Z0-9]+$`,
+	"numeric":  `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":   `^[0-9]+$`,
+}
+
+// validatorFormats validator 标签对应的 JSON Schema format
+var validatorFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid4":            "uuid",
+	"ipv4":             "ipv4",
+	"ipv6":             "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+}
+
+// OpenAPIParameter OpenAPI 参数
+type OpenAPIParameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// OpenAPIOption openapi option
+type OpenAPIOption func(*OpenAPIGenerator)
+
+// WithOpenAPITagName validator 的标签名, 默认为 validate.
+func WithOpenAPITagName(name string) OpenAPIOption {
+	return func(g *OpenAPIGenerator) {
+		g.tagName = name
+	}
+}
+
+// WithOpenAPITagPrefix clip 校验标签的前缀, 与 RegisterValidation 的 WithTagPrefix 相同.
+func WithOpenAPITagPrefix(prefix string) OpenAPIOption {
+	return func(g *OpenAPIGenerator) {
+		g.prefix = prefix
+	}
+}
+
+// OpenAPIGenerator 根据请求结构体的标签生成 OpenAPI 3.1 schema, 使文档与校验保持一致.
+//
+//   - json 标签: 属性名, 带有 query 或 header 标签的字段不属于 body, 见 Parameters
+//   - default 标签: default
+//   - validate 标签: required 以及 min, max, len, gt, lt, eq, oneof, unique, email, datetime 等
+//     validator 标签对应的关键字, dive 之后的标签作用于数组元素
+//   - clip 校验标签: format 为标签名, 如 mobile, idcard, 同时尽量给出 pattern,
+//     decimal 相关标签对应 minimum, exclusiveMinimum, maximum, exclusiveMaximum 和 multipleOf,
+//     字符串和 decimal.Decimal 字段上这些关键字不生效, 改为写入扩展关键字 x-decimal
+//
+// 命名结构体生成到 Components 中并使用 $ref 引用. clip 校验标签对应的 format 同样被 CompileSchema 支持.
+type OpenAPIGenerator struct {
+	tagName    string
+	prefix     string
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+// NewOpenAPIGenerator 新建 OpenAPIGenerator
+func NewOpenAPIGenerator(opts ...OpenAPIOption) *OpenAPIGenerator {
+	g := &OpenAPIGenerator{
+		tagName:    "validate",
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Schema 生成 v 的类型对应的 json body schema, 命名结构体返回 $ref.
+func (g *OpenAPIGenerator) Schema(v any) *Schema {
+	return g.typeSchema(reflect.TypeOf(v))
+}
+
+// Components 已生成的命名结构体 schema, 作为 OpenAPI 的 components.schemas.
+func (g *OpenAPIGenerator) Components() map[string]*Schema {
+	return g.components
+}
+
+// Parameters 生成结构体中带有 query 或 header 标签的字段对应的参数.
+func (g *OpenAPIGenerator) Parameters(v any) []*OpenAPIParameter {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var params []*OpenAPIParameter
+	for _, in := range []string{"query", "header"} {
+		params = g.parameters(params, t, in)
+	}
+	return params
+}
+
+func (g *OpenAPIGenerator) parameters(params []*OpenAPIParameter, t reflect.Type, in string) []*OpenAPIParameter {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tagValue := sf.Tag.Get(in)
+		if tagValue == "-" {
+			continue
+		}
+		if tagValue == "" {
+			ft := sf.Type
+			if sf.Anonymous && ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && (sf.Anonymous || sf.IsExported()) && !isScalarStruct(ft) {
+				params = g.parameters(params, ft, in)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(tagValue, ",")
+		s, required := g.fieldSchema(sf)
+		params = append(params, &OpenAPIParameter{Name: name, In: in, Required: required, Schema: s})
+	}
+	return params
+}
+
+// typeSchema 类型对应的 schema, 不含标签约束
+func (g *OpenAPIGenerator) typeSchema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		if t == fileHeaderType {
+			return &Schema{Type: SchemaType{"string"}, Format: "binary"}
+		}
+		t = t.Elem()
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: SchemaType{"string"}, Format: "date-time"}
+	case decimalType, nullDecimalType:
+		return &Schema{Type: SchemaType{"string"}, Format: "decimal"}
+	case durationType:
+		return &Schema{Type: SchemaType{"integer"}, Format: "int64"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: SchemaType{"boolean"}}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: SchemaType{"integer"}, Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: SchemaType{"integer"}, Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := decimal.Zero
+		return &Schema{Type: SchemaType{"integer"}, Minimum: &zero}
+	case reflect.Float32:
+		return &Schema{Type: SchemaType{"number"}, Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: SchemaType{"number"}, Format: "double"}
+	case reflect.String:
+		return &Schema{Type: SchemaType{"string"}}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: SchemaType{"string"}, Format: "byte"}
+		}
+		return &Schema{Type: SchemaType{"array"}, Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: SchemaType{"object"}, AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if isScalarStruct(t) {
+			return &Schema{Type: SchemaType{"string"}}
+		}
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: openAPIRefPrefix + g.component(t)}
+	default:
+		return &Schema{}
+	}
+}
+
+// component 生成命名结构体的组件, 返回组件名, 不同包的同名结构体使用包名区分.
+func (g *OpenAPIGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := rxComponentName.ReplaceAllString(t.Name(), "_")
+	if _, exist := g.components[name]; exist {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	g.names[t] = name
+	g.components[name] = &Schema{} // 占位, 支持递归引用
+	*g.components[name] = *g.structSchema(t)
+	return name
+}
+
+func (g *OpenAPIGenerator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: SchemaType{"object"}, Properties: make(map[string]*Schema)}
+	g.properties(s, t)
+	return s
+}
+
+// properties 生成结构体的属性, 匿名结构体的属性被展开, 同 encoding/json.
+func (g *OpenAPIGenerator) properties(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		if sf.Tag.Get("query") != "" || sf.Tag.Get("header") != "" {
+			continue
+		}
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && sf.Anonymous {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !isScalarStruct(ft) {
+				g.properties(s, ft)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		ps, required := g.fieldSchema(sf)
+		s.Properties[name] = ps
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// fieldSchema 字段的 schema 以及是否必填
+func (g *OpenAPIGenerator) fieldSchema(sf reflect.StructField) (*Schema, bool) {
+	s := g.typeSchema(sf.Type)
+	if def, ok := sf.Tag.Lookup("default"); ok {
+		s.Default = defaultValue(sf.Type, def)
+	}
+	var tags []string
+	if tag := sf.Tag.Get(g.tagName); tag != "" && tag != "-" {
+		tags = strings.Split(tag, ",")
+	}
+	return s, g.applyTags(s, sf.Type, tags)
+}
+
+// applyTags 将校验标签转为 schema 关键字, 返回是否必填.
+func (g *OpenAPIGenerator) applyTags(s *Schema, t reflect.Type, tags []string) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	required := false
+	for i := 0; i < len(tags); i++ {
+		tag := tags[i]
+		switch {
+		case tag == "dive":
+			var elem *Schema
+			if s.Items != nil {
+				elem = s.Items
+			} else if s.AdditionalProperties != nil {
+				elem = s.AdditionalProperties
+			}
+			if elem != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map) {
+				g.applyTags(elem, t.Elem(), tags[i+1:])
+			}
+			return required
+		case tag == "keys":
+			for i < len(tags) && tags[i] != "endkeys" {
+				i++
+			}
+			continue
+		case strings.Contains(tag, "|"):
+			continue
+		}
+
+		name, param, _ := strings.Cut(tag, "=")
+		if clip := strings.TrimPrefix(name, g.prefix); g.prefix == "" || clip != name {
+			if _, ok := lookupValidation(clip); ok {
+				applyClipTag(s, t, clip, param)
+				continue
+			}
+		}
+		if name == "required" {
+			required = true
+			continue
+		}
+		applyValidatorTag(s, t, name, param)
+	}
+	return required
+}
+
+// applyValidatorTag validator 标签对应的关键字, 不支持的标签被忽略.
+func applyValidatorTag(s *Schema, t reflect.Type, name, param string) {
+	if format, ok := validatorFormats[name]; ok {
+		s.Format = format
+		return
+	}
+	if pattern, ok := patternRegexps[name]; ok {
+		s.Pattern = pattern
+		return
+	}
+	switch name {
+	case "len":
+		setBound(s, t, "min", param)
+		setBound(s, t, "max", param)
+	case "min", "gte", "gt", "max", "lte", "lt":
+		setBound(s, t, name, param)
+	case "eq":
+		if v := jsonValue(t, param); v != nil {
+			s.Const = v
+		}
+	case "oneof":
+		for _, p := range strings.Fields(param) {
+			if v := jsonValue(t, p); v != nil {
+				s.Enum = append(s.Enum, v)
+			}
+		}
+	case "unique":
+		s.UniqueItems = true
+	case "startswith":
+		s.Pattern = "^" + regexp.QuoteMeta(param)
+	case "endswith":
+		s.Pattern = regexp.QuoteMeta(param) + "$"
+	case "datetime":
+		switch param {
+		case time.RFC3339, time.RFC3339Nano:
+			s.Format = "date-time"
+		case "2006-01-02":
+			s.Format = "date"
+		case "15:04:05":
+			s.Format = "time"
+		}
+	}
+}
+
+// applyClipTag clip 校验标签对应的关键字
+func applyClipTag(s *Schema, t reflect.Type, name, param string) {
+	kind := t.Kind()
+	isString := kind == reflect.String
+	isNumber := isNumberType(t)
+
+	switch name {
+	case "decimal", "decimal_gt", "decimal_gte", "decimal_lt", "decimal_lte", "decimal_min", "decimal_max",
+		"decimal_scale", "decimal_precision", "decimal_between", "decimal_multiple_of":
+		if isString {
+			if s.Format == "" {
+				s.Format = "decimal"
+			}
+			if s.Pattern == "" {
+				s.Pattern = clipPatterns["decimal"]
+			}
+		}
+	case "time_rfc3339":
+		s.Format = "date-time"
+	case "time_date":
+		s.Format = "date"
+	case "time_unix":
+		if isString {
+			s.Pattern = numberGte0RegexString
+		}
+	case "number_gt0", "number_gte0", "number_range":
+		if isString {
+			s.Pattern = clipPatterns[name]
+		}
+	case "mobile", "phone", "e164", "idcard", "hk_macau_permit", "taiwan_permit", "uscc", "bank_card", "bank_card_bin",
+		"iban", "swift_bic", "cn_postal_code", "license_plate", "new_energy_plate", "region_code":
+		s.Format = name
+		if pattern, ok := clipPatterns[name]; ok && param == "" {
+			s.Pattern = pattern
+		}
+	}
+
+	// 数值字段使用 minimum 等关键字, 以字符串表示的数值使用 x-decimal
+	b := s.XDecimal
+	if isNumber {
+		b = &DecimalBounds{
+			Minimum:          s.Minimum,
+			Maximum:          s.Maximum,
+			ExclusiveMinimum: s.ExclusiveMinimum,
+			ExclusiveMaximum: s.ExclusiveMaximum,
+			MultipleOf:       s.MultipleOf,
+		}
+	} else if b == nil {
+		b = &DecimalBounds{}
+	}
+	switch name {
+	case "decimal_gt":
+		b.ExclusiveMinimum = decimalPtr(param)
+	case "decimal_gte", "decimal_min":
+		b.Minimum = decimalPtr(param)
+	case "decimal_lt":
+		b.ExclusiveMaximum = decimalPtr(param)
+	case "decimal_lte", "decimal_max":
+		b.Maximum = decimalPtr(param)
+	case "decimal_between":
+		if min, max, err := parseDecimalRangeParam(param); err == nil {
+			b.Minimum, b.Maximum = &min, &max
+		}
+	case "decimal_multiple_of":
+		b.MultipleOf = decimalPtr(param)
+	case "decimal_scale":
+		if n, err := parseDigitsParam(param); err == nil && b.MultipleOf == nil {
+			step := decimal.New(1, -n)
+			b.MultipleOf = &step
+		}
+	case "number_gt0":
+		if !isString {
+			one := decimal.NewFromInt(1)
+			b.Minimum = &one
+		}
+	case "number_gte0", "time_unix":
+		if !isString {
+			zero := decimal.Zero
+			b.Minimum = &zero
+		}
+	case "number_range":
+		if min, max, err := parseNumberRangeParam(param); err == nil {
+			minimum, maximum := decimal.NewFromBigInt(min, 0), decimal.NewFromBigInt(max, 0)
+			b.Minimum, b.Maximum = &minimum, &maximum
+		}
+	}
+	if isNumber {
+		s.Minimum, s.Maximum = b.Minimum, b.Maximum
+		s.ExclusiveMinimum, s.ExclusiveMaximum = b.ExclusiveMinimum, b.ExclusiveMaximum
+		s.MultipleOf = b.MultipleOf
+	} else if *b != (DecimalBounds{}) {
+		s.XDecimal = b
+	}
+}
+
+// isNumberType 是否为 json 数值类型, time.Duration 除外.
+func isNumberType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return t != durationType
+	}
+	return false
+}
+
+// setBound min, max 等标签, 字符串对应长度, 数组和对象对应元素个数, 数值对应大小.
+func setBound(s *Schema, t reflect.Type, name, param string) {
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		switch name {
+		case "gt":
+			n++
+		case "lt":
+			n--
+		}
+		var minp, maxp **int
+		if t.Kind() == reflect.String {
+			minp, maxp = &s.MinLength, &s.MaxLength
+		} else if t.Kind() != reflect.Map {
+			minp, maxp = &s.MinItems, &s.MaxItems
+		} else {
+			return
+		}
+		switch name {
+		case "min", "gte", "gt":
+			*minp = &n
+		default:
+			*maxp = &n
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if t == durationType {
+			return
+		}
+		d := decimalPtr(param)
+		if d == nil {
+			return
+		}
+		switch name {
+		case "min", "gte":
+			s.Minimum = d
+		case "gt":
+			s.ExclusiveMinimum = d
+		case "max", "lte":
+			s.Maximum = d
+		case "lt":
+			s.ExclusiveMaximum = d
+		}
+	}
+}
+
+// jsonValue 将标签参数转为 t 类型的 json 值, 无法转换时返回 nil.
+func jsonValue(t reflect.Type, s string) json.RawMessage {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		b, _ := json.Marshal(s)
+		return b
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil
+		}
+		return json.RawMessage(strconv.FormatBool(b))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if d := decimalPtr(s); d != nil && t != durationType {
+			return json.RawMessage(d.String())
+		}
+	}
+	return nil
+}
+
+// defaultValue default 标签对应的 json 值, 切片的默认值以 ',' 分隔.
+func defaultValue(t reflect.Type, s string) json.RawMessage {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == decimalType {
+		b, _ := json.Marshal(s)
+		return b
+	}
+	if t.Kind() != reflect.Slice || t.Elem().Kind() == reflect.Uint8 {
+		return jsonValue(t, s)
+	}
+	values := make([]json.RawMessage, 0)
+	for _, v := range strings.Split(s, ",") {
+		raw := jsonValue(t.Elem(), v)
+		if raw == nil {
+			return nil
+		}
+		values = append(values, raw)
+	}
+	b, _ := json.Marshal(values)
+	return b
+}
+
+func decimalPtr(s string) *decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return nil
+	}
+	return &d
+}
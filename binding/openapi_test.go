@@ -0,0 +1,165 @@
+package binding
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPIItem struct {
+	SKU      string          `json:"sku" validate:"required,len=8,alphanum"`
+	Price    decimal.Decimal `json:"price" validate:"decimal_gt=0,decimal_scale=2"`
+	Quantity int             `json:"quantity" validate:"min=1,max=99"`
+}
+
+type openAPIPage struct {
+	Page int `query:"page" default:"1" validate:"gte=1"`
+	Size int `query:"size" default:"20" validate:"oneof=10 20 50"`
+}
+
+type openAPINode struct {
+	Name     string         `json:"name"`
+	Children []*openAPINode `json:"children,omitempty"`
+}
+
+type openAPIOrder struct {
+	openAPIPage
+	Token    string            `header:"X-Token" validate:"required"`
+	Mobile   string            `json:"mobile" validate:"required,mobile"`
+	Amount   string            `json:"amount" validate:"decimal_between=0.01~100"`
+	Discount string            `json:"discount,omitempty" validate:"omitempty,decimal_gte=0,decimal_lt=1"`
+	Email    string            `json:"email" validate:"omitempty,email"`
+	Status   string            `json:"status" default:"pending" validate:"oneof=pending paid"`
+	Tags     []string          `json:"tags" validate:"max=5,unique,dive,min=1,max=10"`
+	Items    []openAPIItem     `json:"items" validate:"required,min=1,dive"`
+	Extra    map[string]string `json:"extra"`
+	Date     string            `json:"date" validate:"datetime=2006-01-02"`
+	PaidAt   *time.Time        `json:"paid_at"`
+	Count    uint              `json:"count" validate:"number_gt0"`
+	Tree     *openAPINode      `json:"tree"`
+	Anon     struct {
+		Region string `json:"region" validate:"region_code"`
+	} `json:"anon"`
+	Ignored string `json:"-"`
+	Either  string `json:"either" validate:"email|e164"`
+}
+
+func TestOpenAPISchema(t *testing.T) {
+	g := NewOpenAPIGenerator()
+	s := g.Schema(&openAPIOrder{})
+	require.Equal(t, "#/components/schemas/openAPIOrder", s.Ref)
+
+	b, err := json.Marshal(g.Components())
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+	"openAPIOrder": {
+		"type": "object",
+		"properties": {
+			"mobile": {"type": "string", "format": "mobile", "pattern": "^1\\d{10}$"},
+			"amount": {"type": "string", "format": "decimal", "pattern": "^[-+]?(\\d+\\.?\\d*|\\.\\d+)([eE][-+]?\\d+)?$", "x-decimal": {"minimum": 0.01, "maximum": 100}},
+			"discount": {"type": "string", "format": "decimal", "pattern": "^[-+]?(\\d+\\.?\\d*|\\.\\d+)([eE][-+]?\\d+)?$", "x-decimal": {"minimum": 0, "exclusiveMaximum": 1}},
+			"email": {"type": "string", "format": "email"},
+			"status": {"type": "string", "enum": ["pending", "paid"], "default": "pending"},
+			"tags": {"type": "array", "maxItems": 5, "uniqueItems": true, "items": {"type": "string", "minLength": 1, "maxLength": 10}},
+			"items": {"type": "array", "minItems": 1, "items": {"$ref": "#/components/schemas/openAPIItem"}},
+			"extra": {"type": "object", "additionalProperties": {"type": "string"}},
+			"date": {"type": "string", "format": "date"},
+			"paid_at": {"type": "string", "format": "date-time"},
+			"count": {"type": "integer", "minimum": 1},
+			"tree": {"$ref": "#/components/schemas/openAPINode"},
+			"anon": {"type": "object", "properties": {"region": {"type": "string", "format": "region_code", "pattern": "^\\d{6}$"}}},
+			"either": {"type": "string"}
+		},
+		"required": ["mobile", "items"]
+	},
+	"openAPIItem": {
+		"type": "object",
+		"properties": {
+			"sku": {"type": "string", "minLength": 8, "maxLength": 8, "pattern": "^[a-zA-Z0-9]+$"},
+			"price": {"type": "string", "format": "decimal", "x-decimal": {"exclusiveMinimum": 0, "multipleOf": 0.01}},
+			"quantity": {"type": "integer", "format": "int64", "minimum": 1, "maximum": 99}
+		},
+		"required": ["sku"]
+	},
+	"openAPINode": {
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"children": {"type": "array", "items": {"$ref": "#/components/schemas/openAPINode"}}
+		}
+	}
+}`, string(b))
+}
+
+func TestOpenAPIParameters(t *testing.T) {
+	g := NewOpenAPIGenerator()
+	b, err := json.Marshal(g.Parameters(openAPIOrder{}))
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"name": "page", "in": "query", "schema": {"type": "integer", "format": "int64", "minimum": 1, "default": 1}},
+		{"name": "size", "in": "query", "schema": {"type": "integer", "format": "int64", "enum": [10, 20, 50], "default": 20}},
+		{"name": "X-Token", "in": "header", "required": true, "schema": {"type": "string"}}
+	]`, string(b))
+	require.Nil(t, g.Parameters(1))
+}
+
+func TestOpenAPITypes(t *testing.T) {
+	type Upload struct {
+		File    *multipart.FileHeader `json:"file"`
+		Data    []byte                `json:"data"`
+		Timeout time.Duration         `json:"timeout" validate:"min=1s"`
+		Ratio   float32               `json:"ratio" validate:"gt=0,lt=1"`
+		Enabled bool                  `json:"enabled" default:"true" validate:"eq=true"`
+		Codes   []int                 `json:"codes" default:"1,2"`
+		Any     any                   `json:"any"`
+		Mobile  string                `json:"mobile" validate:"clip_mobile=HK"`
+		Plain   string                `json:"plain" validate:"mobile"`
+		ID      string                `json:"id" validate:"clip_number_range=1~18446744073709551616"`
+		StartAt string                `json:"start_at" validate:"clip_time_rfc3339,clip_time_after=now"`
+		Unix    int64                 `json:"unix" validate:"clip_time_unix"`
+	}
+	g := NewOpenAPIGenerator(WithOpenAPITagPrefix("clip_"))
+	g.Schema(Upload{})
+	b, err := json.Marshal(g.Components()["Upload"])
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"file": {"type": "string", "format": "binary"},
+			"data": {"type": "string", "format": "byte"},
+			"timeout": {"type": "integer", "format": "int64"},
+			"ratio": {"type": "number", "format": "float", "exclusiveMinimum": 0, "exclusiveMaximum": 1},
+			"enabled": {"type": "boolean", "const": true, "default": true},
+			"codes": {"type": "array", "items": {"type": "integer", "format": "int64"}, "default": [1, 2]},
+			"any": {},
+			"mobile": {"type": "string", "format": "mobile"},
+			"plain": {"type": "string"},
+			"id": {"type": "string", "pattern": "^-?\\d+$", "x-decimal": {"minimum": 1, "maximum": 18446744073709551616}},
+			"start_at": {"type": "string", "format": "date-time"},
+			"unix": {"type": "integer", "format": "int64", "minimum": 0}
+		}
+	}`, string(b))
+}
+
+func TestOpenAPISchemaFormats(t *testing.T) {
+	type Account struct {
+		Mobile string `json:"mobile" validate:"mobile"`
+		Amount string `json:"amount" validate:"decimal_gt=0"`
+	}
+	g := NewOpenAPIGenerator()
+	g.Schema(Account{})
+	b, err := json.Marshal(g.Components()["Account"])
+	require.NoError(t, err)
+
+	sv, err := CompileSchema(b)
+	require.NoError(t, err)
+	require.NoError(t, sv.Validate(Account{Mobile: "13800138000", Amount: "1"}))
+	require.Error(t, sv.Validate(Account{Mobile: "12345678901", Amount: "1"}))
+	for _, amount := range []string{"-5", "0", "abc"} {
+		require.Error(t, sv.Validate(Account{Mobile: "13800138000", Amount: amount}), amount)
+	}
+}
@@ -0,0 +1,306 @@
+package binding
+
+import (
+	_ "embed" // embed phone metadata
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// phoneMetadataJSON 内置的号段表, 号段变更时更新 phone_metadata.json 即可.
+//
+//go:embed phone_metadata.json
+var phoneMetadataJSON []byte
+
+// error defined
+var (
+	ErrPhoneInvalid       = errors.New("phone: invalid phone number")
+	ErrPhoneUnknownRegion = errors.New("phone: unknown region")
+)
+
+// PhoneType 号码类型
+type PhoneType string
+
+// PhoneType defined
+const (
+	// PhoneMobile 移动电话
+	PhoneMobile PhoneType = "mobile"
+	// PhoneLandline 固定电话
+	PhoneLandline PhoneType = "landline"
+	// PhoneVirtual 虚拟运营商, 如中国的 170/171 号段
+	PhoneVirtual PhoneType = "virtual"
+	// PhoneFixedOrMobile 无法从号段区分固话和移动电话, 如北美编号计划
+	PhoneFixedOrMobile PhoneType = "fixed_or_mobile"
+)
+
+// IsMobile 是否可作为手机号, 包括虚拟运营商号段.
+func (t PhoneType) IsMobile() bool {
+	return t == PhoneMobile || t == PhoneVirtual || t == PhoneFixedOrMobile
+}
+
+// PhoneClass 某一类号码的号段规则
+type PhoneClass struct {
+	// Type 号码类型
+	Type PhoneType `json:"type"`
+	// Lengths 国内有效号码(不含国内长途前缀)的长度
+	Lengths []int `json:"lengths"`
+	// Prefixes 号段前缀
+	Prefixes []string `json:"prefixes"`
+}
+
+// PhoneRegion 地区的号码规则
+type PhoneRegion struct {
+	// CountryCode 国际电话区号, 如 86
+	CountryCode string `json:"country_code"`
+	// TrunkPrefix 国内长途前缀, 如 0
+	TrunkPrefix string `json:"trunk_prefix,omitempty"`
+	// Classes 号段规则
+	Classes []PhoneClass `json:"classes"`
+}
+
+// PhoneMetadata 各地区的号码规则, key 为 ISO 3166-1 地区码, 如 CN.
+type PhoneMetadata map[string]*PhoneRegion
+
+var phoneMetadata atomic.Value // PhoneMetadata
+
+func init() {
+	if err := LoadPhoneMetadata(strings.NewReader(string(phoneMetadataJSON))); err != nil {
+		panic(err)
+	}
+}
+
+// LoadPhoneMetadata 从 json 中加载号段表, 替换当前的号段表, 格式同内置的 phone_metadata.json.
+func LoadPhoneMetadata(r io.Reader) error {
+	var md PhoneMetadata
+	if err := json.NewDecoder(r).Decode(&md); err != nil {
+		return fmt.Errorf("phone: %w", err)
+	}
+	return SetPhoneMetadata(md)
+}
+
+// SetPhoneMetadata 替换当前的号段表, 并发安全.
+func SetPhoneMetadata(md PhoneMetadata) error {
+	normalized := make(PhoneMetadata, len(md))
+	for region, r := range md {
+		if r == nil || r.CountryCode == "" || !isDigits(r.CountryCode) {
+			return fmt.Errorf("phone: region %q has invalid country code", region)
+		}
+		normalized[strings.ToUpper(region)] = r
+	}
+	phoneMetadata.Store(normalized)
+	return nil
+}
+
+// GetPhoneMetadata 获取当前的号段表, 返回值不可修改.
+func GetPhoneMetadata() PhoneMetadata {
+	return phoneMetadata.Load().(PhoneMetadata)
+}
+
+// PhoneNumber 解析后的电话号码
+type PhoneNumber struct {
+	// Region 地区码, 如 CN
+	Region string
+	// CountryCode 国际电话区号, 如 86
+	CountryCode string
+	// National 国内有效号码, 不含国内长途前缀
+	National string
+	// Type 号码类型
+	Type PhoneType
+}
+
+// E164 E.164 格式, 如 +8613800138000
+func (p *PhoneNumber) E164() string {
+	return "+" + p.CountryCode + p.National
+}
+
+// NationalFormat 国内格式, 固话带有国内长途前缀, 如 13800138000, 01012345678.
+func (p *PhoneNumber) NationalFormat() string {
+	if p.Type == PhoneLandline {
+		if r, ok := GetPhoneMetadata()[p.Region]; ok {
+			return r.TrunkPrefix + p.National
+		}
+	}
+	return p.National
+}
+
+// ParsePhone 解析电话号码.
+// 以 + 或 00 开头的号码按国际格式解析, 否则按 defaultRegion 的国内格式解析.
+// 号码中的空格, '-', '.', '(', ')' 会被忽略.
+func ParsePhone(s, defaultRegion string) (*PhoneNumber, error) {
+	md := GetPhoneMetadata()
+	digits, international := cleanPhone(s)
+	if digits == "" {
+		return nil, ErrPhoneInvalid
+	}
+
+	if international {
+		for _, region := range regionsOf(md) {
+			r := md[region]
+			if !strings.HasPrefix(digits, r.CountryCode) {
+				continue
+			}
+			if p, ok := r.match(region, digits[len(r.CountryCode):]); ok {
+				return p, nil
+			}
+		}
+		return nil, ErrPhoneInvalid
+	}
+
+	region := strings.ToUpper(defaultRegion)
+	r, ok := md[region]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrPhoneUnknownRegion, defaultRegion)
+	}
+	if p, ok := r.match(region, digits); ok {
+		return p, nil
+	}
+	if r.TrunkPrefix != "" && strings.HasPrefix(digits, r.TrunkPrefix) {
+		if p, ok := r.match(region, digits[len(r.TrunkPrefix):]); ok {
+			return p, nil
+		}
+	}
+	// 不带 + 的国际格式, 如 8613800138000
+	if strings.HasPrefix(digits, r.CountryCode) {
+		if p, ok := r.match(region, digits[len(r.CountryCode):]); ok {
+			return p, nil
+		}
+	}
+	return nil, ErrPhoneInvalid
+}
+
+// NormalizePhone 解析电话号码并返回 E.164 格式, 见 ParsePhone.
+func NormalizePhone(s, defaultRegion string) (string, error) {
+	p, err := ParsePhone(s, defaultRegion)
+	if err != nil {
+		return "", err
+	}
+	return p.E164(), nil
+}
+
+// IsMobileOf 是否是 region 地区的手机号, 支持国内格式和国际格式.
+func IsMobileOf(s, region string) bool {
+	p, err := ParsePhone(s, region)
+	return err == nil && p.Region == strings.ToUpper(region) && p.Type.IsMobile()
+}
+
+// IsPhoneOf 是否是 region 地区的电话号码, 包括固话, 支持国内格式和国际格式.
+func IsPhoneOf(s, region string) bool {
+	p, err := ParsePhone(s, region)
+	return err == nil && p.Region == strings.ToUpper(region)
+}
+
+// IsE164 是否是 E.164 格式的电话号码, 如 +8613800138000.
+// 号段表中存在该国际电话区号时, 还需要符合该地区的号段规则.
+func IsE164(s string) bool {
+	if len(s) < 3 || len(s) > 16 || s[0] != '+' || s[1] == '0' || !isDigits(s[1:]) {
+		return false
+	}
+	md := GetPhoneMetadata()
+	digits := s[1:]
+	known := false
+	for _, region := range regionsOf(md) {
+		r := md[region]
+		if !strings.HasPrefix(digits, r.CountryCode) {
+			continue
+		}
+		known = true
+		if _, ok := r.match(region, digits[len(r.CountryCode):]); ok {
+			return true
+		}
+	}
+	return !known
+}
+
+// match 按号段最长前缀匹配国内有效号码
+func (r *PhoneRegion) match(region, national string) (*PhoneNumber, bool) {
+	if !isDigits(national) {
+		return nil, false
+	}
+	var matched *PhoneClass
+	matchedLen := 0
+	for i := range r.Classes {
+		c := &r.Classes[i]
+		if !containsInt(c.Lengths, len(national)) {
+			continue
+		}
+		for _, prefix := range c.Prefixes {
+			if len(prefix) > matchedLen && strings.HasPrefix(national, prefix) {
+				matched, matchedLen = c, len(prefix)
+			}
+		}
+	}
+	if matched == nil {
+		return nil, false
+	}
+	return &PhoneNumber{
+		Region:      region,
+		CountryCode: r.CountryCode,
+		National:    national,
+		Type:        matched.Type,
+	}, true
+}
+
+// regionsOf 地区码, 国际电话区号长的优先, 以保证匹配结果稳定.
+func regionsOf(md PhoneMetadata) []string {
+	regions := make([]string, 0, len(md))
+	for region := range md {
+		regions = append(regions, region)
+	}
+	sort.Slice(regions, func(i, j int) bool {
+		ci, cj := md[regions[i]].CountryCode, md[regions[j]].CountryCode
+		if len(ci) != len(cj) {
+			return len(ci) > len(cj)
+		}
+		return regions[i] < regions[j]
+	})
+	return regions
+}
+
+// cleanPhone 去除分隔符, 返回数字以及是否为国际格式
+func cleanPhone(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	international := false
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s, international = s[1:], true
+	case strings.HasPrefix(s, "00"):
+		s, international = s[2:], true
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			b.WriteRune(c)
+		case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')':
+		default:
+			return "", false
+		}
+	}
+	return b.String(), international
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func containsInt(ns []int, n int) bool {
+	for _, v := range ns {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
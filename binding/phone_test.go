@@ -0,0 +1,129 @@
+package binding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		region string
+		want   string
+		typ    PhoneType
+		wantR  string
+	}{
+		{"cn mobile", "13800138000", "CN", "+8613800138000", PhoneMobile, "CN"},
+		{"cn new prefix", "19212345678", "CN", "+8619212345678", PhoneMobile, "CN"},
+		{"cn virtual", "170 1234 5678", "CN", "+8617012345678", PhoneVirtual, "CN"},
+		{"cn virtual 162", "16212345678", "CN", "+8616212345678", PhoneVirtual, "CN"},
+		{"cn landline", "010-12345678", "CN", "+861012345678", PhoneLandline, "CN"},
+		{"cn landline 4 digits area", "(0755) 1234 5678", "CN", "+8675512345678", PhoneLandline, "CN"},
+		{"cn without plus", "8613800138000", "CN", "+8613800138000", PhoneMobile, "CN"},
+		{"international", "+86 138-0013-8000", "", "+8613800138000", PhoneMobile, "CN"},
+		{"international 00", "0085261234567", "CN", "+85261234567", PhoneMobile, "HK"},
+		{"hk landline", "2123 4567", "hk", "+85221234567", PhoneLandline, "HK"},
+		{"us", "+1 (415) 555-2671", "", "+14155552671", PhoneFixedOrMobile, "US"},
+		{"us trunk", "1 415 555 2671", "US", "+14155552671", PhoneFixedOrMobile, "US"},
+		{"gb trunk", "07400 123456", "GB", "+447400123456", PhoneMobile, "GB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePhone(tt.s, tt.region)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, p.E164())
+			require.Equal(t, tt.typ, p.Type)
+			require.Equal(t, tt.wantR, p.Region)
+
+			got, err := NormalizePhone(tt.s, tt.region)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, s := range []string{"", "abc", "1380013800", "12345678901", "+999123456", "+86 138 0013 8000 1"} {
+		_, err := ParsePhone(s, "CN")
+		require.ErrorIs(t, err, ErrPhoneInvalid, s)
+	}
+	_, err := ParsePhone("13800138000", "XX")
+	require.ErrorIs(t, err, ErrPhoneUnknownRegion)
+	_, err = ParsePhone("13800138000", "")
+	require.ErrorIs(t, err, ErrPhoneUnknownRegion)
+}
+
+func TestIsMobileOf(t *testing.T) {
+	for _, s := range []string{"16212345678", "16512345678", "16712345678", "19012345678", "19212345678",
+		"19312345678", "19512345678", "19612345678", "19712345678", "17112345678"} {
+		require.True(t, IsMobile(s), s)
+	}
+	require.False(t, IsMobile("+8613800138000"))
+	require.False(t, IsMobile("01012345678"))
+	require.False(t, IsMobile("14012345678"))
+
+	require.True(t, IsMobileOf("+86 13800138000", "CN"))
+	require.True(t, IsMobileOf("61234567", "HK"))
+	require.False(t, IsMobileOf("+85261234567", "CN"))
+	require.False(t, IsMobileOf("01012345678", "CN"))
+	require.True(t, IsPhoneOf("01012345678", "CN"))
+}
+
+func TestIsE164(t *testing.T) {
+	require.True(t, IsE164("+8613800138000"))
+	require.True(t, IsE164("+14155552671"))
+	require.True(t, IsE164("+4930123456")) // 号段表中没有的地区只校验格式
+	require.False(t, IsE164("+8612345678901"))
+	require.False(t, IsE164("8613800138000"))
+	require.False(t, IsE164("+0123456"))
+	require.False(t, IsE164("+86 13800138000"))
+	require.False(t, IsE164("+1234567890123456"))
+}
+
+func TestLoadPhoneMetadata(t *testing.T) {
+	old := GetPhoneMetadata()
+	defer func() { require.NoError(t, SetPhoneMetadata(old)) }()
+
+	err := LoadPhoneMetadata(strings.NewReader(`{"cn": {"country_code": "86", "classes": [{"type": "mobile", "lengths": [11], "prefixes": ["140"]}]}}`))
+	require.NoError(t, err)
+	require.True(t, IsMobile("14012345678"))
+	require.False(t, IsMobile("13800138000"))
+
+	require.Error(t, LoadPhoneMetadata(strings.NewReader(`{"CN": {"country_code": "+86"}}`)))
+	require.Error(t, LoadPhoneMetadata(strings.NewReader(`[`)))
+}
+
+func TestValidPhone(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type Contact struct {
+		Mobile   string `validate:"mobile"`
+		HKMobile string `validate:"omitempty,mobile=HK"`
+		Phone    string `validate:"omitempty,phone=CN"`
+		Intl     string `validate:"omitempty,phone"`
+		E164     string `validate:"omitempty,e164"`
+	}
+	require.NoError(t, valid.Struct(&Contact{
+		Mobile:   "19612345678",
+		HKMobile: "+852 6123 4567",
+		Phone:    "010-12345678",
+		Intl:     "+1 415 555 2671",
+		E164:     "+8613800138000",
+	}))
+
+	err := valid.Struct(&Contact{
+		Mobile:   "01012345678",
+		HKMobile: "13800138000",
+		Phone:    "123",
+		Intl:     "13800138000",
+		E164:     "+8612345678901",
+	})
+	require.Equal(t, []string{
+		"Mobile:mobile",
+		"HKMobile:mobile",
+		"Phone:phone",
+		"Intl:phone",
+		"E164:e164",
+	}, fieldErrorTags(t, err))
+}
@@ -0,0 +1,26 @@
+package binding
+
+import (
+	"regexp"
+)
+
+// plateProvinces 号牌省份简称
+const plateProvinces = "京津沪渝冀豫云辽黑湘皖鲁新苏浙赣鄂桂甘晋蒙陕吉闽贵粤青藏川宁琼"
+
+var (
+	// rxLicensePlate 普通号牌, 省份简称 + 发牌机关代号 + 5 位序号, 末位可为挂, 学, 警, 港, 澳. 序号不使用 I, O.
+	rxLicensePlate = regexp.MustCompile(`^[` + plateProvinces + `][A-HJ-NP-Z][A-HJ-NP-Z0-9]{4}[A-HJ-NP-Z0-9挂学警港澳]$`)
+	// rxNewEnergyPlate 新能源号牌, 省份简称 + 发牌机关代号 + 6 位序号.
+	// 小型车序号首位, 大型车序号末位为能源类型: D, A, B, C, E 为纯电动, F, G, H, J, K 为非纯电动.
+	rxNewEnergyPlate = regexp.MustCompile(`^[` + plateProvinces + `][A-HJ-NP-Z]([A-HJK][A-HJ-NP-Z0-9]\d{4}|\d{5}[A-HJK])$`)
+)
+
+// IsLicensePlate 是否是机动车号牌, 包括 7 位普通号牌和 8 位新能源号牌, 如 京A12345, 粤BD12345.
+func IsLicensePlate(s string) bool {
+	return rxLicensePlate.MatchString(s) || rxNewEnergyPlate.MatchString(s)
+}
+
+// IsNewEnergyPlate 是否是 8 位新能源号牌, 如 粤BD12345, 沪A12345F.
+func IsNewEnergyPlate(s string) bool {
+	return rxNewEnergyPlate.MatchString(s)
+}
@@ -0,0 +1,36 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicensePlate(t *testing.T) {
+	tests := []struct {
+		plate     string
+		valid     bool
+		newEnergy bool
+	}{
+		{"京A12345", true, false},
+		{"粤BA1234", true, false},
+		{"沪C1234挂", true, false},
+		{"粤Z1234港", true, false},
+		{"粤BD12345", true, true},
+		{"粤BFA1234", true, true},
+		{"沪A12345F", true, true},
+		{"京AD1234", true, false},
+		{"京I12345", false, false},
+		{"京A1234O", false, false},
+		{"京A1234", false, false},
+		{"京A123456", false, false},
+		{"粤BD1234X", false, false},
+		{"沪A12345Z", false, false},
+		{"A12345", false, false},
+		{"港A12345", false, false},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.valid, IsLicensePlate(tt.plate), tt.plate)
+		require.Equal(t, tt.newEnergy, IsNewEnergyPlate(tt.plate), tt.plate)
+	}
+}
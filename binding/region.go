@@ -0,0 +1,196 @@
+package binding
+
+import (
+	"bufio"
+	_ "embed" // embed region table
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// regionTXT 内置的行政区划代码表(GB/T 2260), 包含全部省级, 地级和县级行政区划.
+// 由 internal/regiongen 根据民政部发布的县以上行政区划代码生成, 更新时执行
+//
+//	GB2260_VERSION=2023 GB2260_SOURCE=<民政部代码表的 url 或文件> go generate ./binding
+//
+// 未设置环境变量时生成失败, region.txt 不会被修改.
+//
+//go:generate go run ./internal/regiongen -version=$GB2260_VERSION -o region.txt "$GB2260_SOURCE"
+//go:embed region.txt
+var regionTXT []byte
+
+// RegionLevel 行政区划级别
+type RegionLevel string
+
+// RegionLevel defined
+const (
+	// RegionProvince 省级, 代码后 4 位为 0
+	RegionProvince RegionLevel = "province"
+	// RegionCity 地级, 代码后 2 位为 0
+	RegionCity RegionLevel = "city"
+	// RegionDistrict 县级
+	RegionDistrict RegionLevel = "district"
+)
+
+// Region 行政区划
+type Region struct {
+	// Code 6 位行政区划代码
+	Code string
+	// Name 名称
+	Name string
+	// Level 级别
+	Level RegionLevel
+}
+
+// rxCNPostalCode 中国邮政编码, 6 位数字, 前两位为 01~86.
+var rxCNPostalCode = regexp.MustCompile(`^(0[1-9]|[1-7]\d|8[0-6])\d{4}$`)
+
+var regions atomic.Value // map[string]string
+
+func init() {
+	if err := LoadRegions(strings.NewReader(string(regionTXT))); err != nil {
+		panic(err)
+	}
+}
+
+// LoadRegions 加载行政区划代码表, 替换当前的代码表, 格式同内置的 region.txt.
+// 每行为 "代码 名称", 空行和以 # 开头的行被忽略.
+func LoadRegions(r io.Reader) error {
+	mp := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return fmt.Errorf("region: line %d: invalid format %q", line, text)
+		}
+		mp[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("region: %w", err)
+	}
+	return SetRegions(mp)
+}
+
+// SetRegions 替换当前的行政区划代码表, key 为 6 位代码, value 为名称, 并发安全.
+func SetRegions(mp map[string]string) error {
+	table := make(map[string]string, len(mp))
+	for code, name := range mp {
+		if len(code) != 6 || !isDigits(code) || code[:2] == "00" || name == "" {
+			return fmt.Errorf("region: invalid region %q %q", code, name)
+		}
+		table[code] = name
+	}
+	regions.Store(table)
+	return nil
+}
+
+// LookupRegion 根据代码查找行政区划
+func LookupRegion(code string) (*Region, bool) {
+	name, ok := regions.Load().(map[string]string)[code]
+	if !ok {
+		return nil, false
+	}
+	return &Region{Code: code, Name: name, Level: regionLevel(code)}, true
+}
+
+// RegionParent 上级行政区划.
+// 县级的上级为所属地级, 所属地级不存在时(如直辖市的区, 省直辖县级市)为所属省级.
+func RegionParent(code string) (*Region, bool) {
+	if _, ok := LookupRegion(code); !ok {
+		return nil, false
+	}
+	switch regionLevel(code) {
+	case RegionDistrict:
+		if r, ok := LookupRegion(code[:4] + "00"); ok {
+			return r, true
+		}
+		return LookupRegion(code[:2] + "0000")
+	case RegionCity:
+		return LookupRegion(code[:2] + "0000")
+	default:
+		return nil, false
+	}
+}
+
+// RegionPath 从省级到该行政区划的路径, 如 440305 => [广东省 深圳市 南山区], 代码不存在时返回 nil.
+func RegionPath(code string) []*Region {
+	r, ok := LookupRegion(code)
+	if !ok {
+		return nil
+	}
+	path := []*Region{r}
+	for {
+		parent, ok := RegionParent(r.Code)
+		if !ok {
+			break
+		}
+		path = append([]*Region{parent}, path...)
+		r = parent
+	}
+	return path
+}
+
+// RegionFullName 完整名称, 如 440305 => 广东省深圳市南山区.
+func RegionFullName(code string) (string, bool) {
+	path := RegionPath(code)
+	if path == nil {
+		return "", false
+	}
+	var b strings.Builder
+	for _, r := range path {
+		b.WriteString(r.Name)
+	}
+	return b.String(), true
+}
+
+// IsRegionCode 是否是代码表中的行政区划代码, 指定 levels 时, 级别必须是其中之一.
+func IsRegionCode(code string, levels ...RegionLevel) bool {
+	r, ok := LookupRegion(code)
+	if !ok {
+		return false
+	}
+	if len(levels) == 0 {
+		return true
+	}
+	for _, level := range levels {
+		if level == r.Level {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRegionIn 行政区划 code 是否隶属于 ancestor, 如 440305(南山区) 隶属于 440300(深圳市) 和 440000(广东省).
+// code 与 ancestor 相同时返回 false.
+func IsRegionIn(code, ancestor string) bool {
+	path := RegionPath(code)
+	for i := 0; i < len(path)-1; i++ {
+		if path[i].Code == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCNPostalCode 是否是中国邮政编码, 如 100000.
+func IsCNPostalCode(s string) bool {
+	return rxCNPostalCode.MatchString(s)
+}
+
+// regionLevel 根据代码判断级别
+func regionLevel(code string) RegionLevel {
+	switch {
+	case code[2:] == "0000":
+		return RegionProvince
+	case code[4:] == "00":
+		return RegionCity
+	default:
+		return RegionDistrict
+	}
+}
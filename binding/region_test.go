@@ -0,0 +1,133 @@
+package binding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupRegion(t *testing.T) {
+	r, ok := LookupRegion("440305")
+	require.True(t, ok)
+	require.Equal(t, &Region{Code: "440305", Name: "南山区", Level: RegionDistrict}, r)
+
+	_, ok = LookupRegion("440399")
+	require.False(t, ok)
+
+	tests := []struct {
+		code     string
+		parent   string
+		fullName string
+	}{
+		{"440000", "", "广东省"},
+		{"440300", "440000", "广东省深圳市"},
+		{"440305", "440300", "广东省深圳市南山区"},
+		{"130102", "130100", "河北省石家庄市长安区"},
+		{"652801", "652800", "新疆维吾尔自治区巴音郭楞蒙古自治州库尔勒市"},
+		{"469002", "460000", "海南省琼海市"},
+		{"110105", "110000", "北京市朝阳区"},    // 直辖市的区
+		{"429004", "420000", "湖北省仙桃市"},    // 省直辖县级市
+		{"330182", "330100", "浙江省杭州市建德市"}, // 县级市
+	}
+	for _, tt := range tests {
+		parent, ok := RegionParent(tt.code)
+		if tt.parent == "" {
+			require.False(t, ok, tt.code)
+		} else {
+			require.True(t, ok, tt.code)
+			require.Equal(t, tt.parent, parent.Code, tt.code)
+		}
+		name, ok := RegionFullName(tt.code)
+		require.True(t, ok, tt.code)
+		require.Equal(t, tt.fullName, name)
+	}
+	_, ok = RegionFullName("999999")
+	require.False(t, ok)
+}
+
+func TestIsRegion(t *testing.T) {
+	require.True(t, IsRegionCode("440305"))
+	require.True(t, IsRegionCode("440300", RegionCity))
+	require.True(t, IsRegionCode("440305", RegionCity, RegionDistrict))
+	require.False(t, IsRegionCode("440305", RegionProvince))
+	require.False(t, IsRegionCode("44030"))
+
+	// 内置代码表包含全部县级行政区划
+	table := regions.Load().(map[string]string)
+	require.Greater(t, len(table), 3000)
+	for code := range table {
+		_, ok := table[code[:2]+"0000"]
+		require.True(t, ok, code)
+	}
+
+	require.True(t, IsRegionIn("440305", "440300"))
+	require.True(t, IsRegionIn("440305", "440000"))
+	require.True(t, IsRegionIn("110105", "110000"))
+	require.False(t, IsRegionIn("440305", "440305"))
+	require.False(t, IsRegionIn("440305", "310000"))
+	require.False(t, IsRegionIn("440399", "440000"))
+}
+
+func TestLoadRegions(t *testing.T) {
+	old := regions.Load()
+	defer regions.Store(old)
+
+	require.NoError(t, LoadRegions(strings.NewReader("# test\n\n990000 测试省\n990100 测试市\n")))
+	name, ok := RegionFullName("990100")
+	require.True(t, ok)
+	require.Equal(t, "测试省测试市", name)
+	require.False(t, IsRegionCode("440305"))
+
+	require.Error(t, LoadRegions(strings.NewReader("990000\n")))
+	require.Error(t, LoadRegions(strings.NewReader("99000 测试省\n")))
+	require.Error(t, LoadRegions(strings.NewReader("000000 测试省\n")))
+}
+
+func TestIsCNPostalCode(t *testing.T) {
+	require.True(t, IsCNPostalCode("100000"))
+	require.True(t, IsCNPostalCode("010010"))
+	require.True(t, IsCNPostalCode("518057"))
+	require.False(t, IsCNPostalCode("000000"))
+	require.False(t, IsCNPostalCode("900000"))
+	require.False(t, IsCNPostalCode("10000"))
+	require.False(t, IsCNPostalCode("10000a"))
+}
+
+func TestValidAddress(t *testing.T) {
+	valid := newTestValidate(t)
+
+	type Address struct {
+		Province   string `validate:"region_code=province"`
+		City       string `validate:"region_code=city,region_in=Province"`
+		District   string `validate:"region_code=district,region_in=City"`
+		PostalCode string `validate:"omitempty,cn_postal_code"`
+		Plate      string `validate:"omitempty,license_plate"`
+		NewEnergy  string `validate:"omitempty,new_energy_plate"`
+	}
+	require.NoError(t, valid.Struct(&Address{
+		Province:   "440000",
+		City:       "440300",
+		District:   "440305",
+		PostalCode: "518057",
+		Plate:      "粤B12345",
+		NewEnergy:  "粤BD12345",
+	}))
+
+	err := valid.Struct(&Address{
+		Province:   "440300",
+		City:       "330100",
+		District:   "440305",
+		PostalCode: "51805",
+		Plate:      "粤B1234",
+		NewEnergy:  "粤B12345",
+	})
+	require.Equal(t, []string{
+		"Province:region_code",
+		"City:region_in",
+		"District:region_in",
+		"PostalCode:cn_postal_code",
+		"Plate:license_plate",
+		"NewEnergy:new_energy_plate",
+	}, fieldErrorTags(t, err))
+}
@@ -0,0 +1,784 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+)
+
+// schemaRoot 校验错误中命名空间的根
+const schemaRoot = "$"
+
+// error defined
+var (
+	ErrSchemaInvalid = errors.New("schema: invalid schema")
+	ErrSchemaRef     = errors.New("schema: unresolvable $ref")
+)
+
+// schemaFormats 可作为 format 使用的 clip 校验函数, format 名即为标签名.
+var schemaFormats = map[string]func(string) bool{
+	"mobile":          IsMobile,
+	"phone":           func(s string) bool { _, err := ParsePhone(s, ""); return err == nil },
+	"e164":            IsE164,
+	"idcard":          IsIDCard,
+	"hk_macau_permit": IsHKMacauPermit,
+	"taiwan_permit":   IsTaiwanPermit,
+	"uscc":            IsUSCC,
+	"bank_card":       func(s string) bool { return IsBankCard(s) },
+	"bank_card_bin":   IsBankCardInBins,
+	"iban":            IsIBAN,
+	"swift_bic":       IsSwiftBic,
+	"decimal":         IsDecimal,
+	"number_gt0":      IsNumberGt0,
+	"number_gte0":     IsNumberGte0,
+}
+
+// standardFormats JSON Schema 标准 format 对应的 validator 标签
+var standardFormats = map[string]string{
+	"date-time": "datetime=2006-01-02T15:04:05Z07:00",
+	"date":      "datetime=2006-01-02",
+	"time":      "datetime=15:04:05",
+	"email":     "email",
+	"hostname":  "hostname_rfc1123",
+	"ipv4":      "ipv4",
+	"ipv6":      "ipv6",
+	"uri":       "uri",
+	"uuid":      "uuid",
+}
+
+// SchemaType JSON Schema type, 可以是字符串或字符串数组.
+type SchemaType []string
+
+// MarshalJSON implement json.Marshaler, 单个类型时序列化为字符串.
+func (t SchemaType) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON implement json.Unmarshaler
+func (t *SchemaType) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*t = SchemaType{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*t = ss
+	return nil
+}
+
+// Schema JSON Schema(draft 2020-12 子集).
+//
+// 支持的关键字:
+//   - 通用: type, enum, const, $ref(仅限本文档的 #/$defs/...), $defs, allOf, anyOf, oneOf, not
+//   - 对象: properties, required, additionalProperties
+//   - 数组: items, minItems, maxItems, uniqueItems
+//   - 字符串: minLength, maxLength, pattern, format
+//   - 数值: minimum, maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, 使用 decimal 精确比较
+//   - 扩展: x-decimal, 以字符串表示的数值的范围, 见 DecimalBounds
+//
+// default 仅作为注解, 不参与校验.
+//
+// format 支持 date-time, date, time, email, hostname, ipv4, ipv6, uri, uuid,
+// clip 的校验标签如 mobile, decimal, number_gt0, 以及 WithSchemaFormat 添加的自定义 format, 其它 format 被忽略.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	Type                 SchemaType         `json:"type,omitempty"`
+	Enum                 []json.RawMessage  `json:"enum,omitempty"`
+	Const                json.RawMessage    `json:"const,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Not                  *Schema            `json:"not,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Minimum              *decimal.Decimal   `json:"minimum,omitempty"`
+	Maximum              *decimal.Decimal   `json:"maximum,omitempty"`
+	ExclusiveMinimum     *decimal.Decimal   `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *decimal.Decimal   `json:"exclusiveMaximum,omitempty"`
+	MultipleOf           *decimal.Decimal   `json:"multipleOf,omitempty"`
+	XDecimal             *DecimalBounds     `json:"x-decimal,omitempty"`
+	// Default 注解, 校验时忽略
+	Default json.RawMessage `json:"default,omitempty"`
+
+	// boolean 为 true/false 形式的 schema
+	boolean *bool
+	ref     *Schema
+	pattern *regexp.Regexp
+	enum    []any
+	konst   any
+}
+
+// UnmarshalJSON implement json.Unmarshaler, 支持 true/false 形式的 schema.
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	var boolean bool
+	if err := json.Unmarshal(b, &boolean); err == nil {
+		*s = Schema{boolean: &boolean}
+		return nil
+	}
+	type schema Schema
+	return json.Unmarshal(b, (*schema)(s))
+}
+
+// MarshalJSON implement json.Marshaler
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+	type schema Schema
+	// decimal.Decimal 默认序列化为字符串, 数值关键字需序列化为 json 数值.
+	return json.Marshal(&struct {
+		*schema
+		Minimum          json.RawMessage `json:"minimum,omitempty"`
+		Maximum          json.RawMessage `json:"maximum,omitempty"`
+		ExclusiveMinimum json.RawMessage `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum json.RawMessage `json:"exclusiveMaximum,omitempty"`
+		MultipleOf       json.RawMessage `json:"multipleOf,omitempty"`
+	}{
+		schema:           (*schema)(s),
+		Minimum:          jsonNumber(s.Minimum),
+		Maximum:          jsonNumber(s.Maximum),
+		ExclusiveMinimum: jsonNumber(s.ExclusiveMinimum),
+		ExclusiveMaximum: jsonNumber(s.ExclusiveMaximum),
+		MultipleOf:       jsonNumber(s.MultipleOf),
+	})
+}
+
+// DecimalBounds 扩展关键字 x-decimal, 约束以字符串表示的数值, 如 decimal.Decimal 字段和带有 decimal_gt 等标签的字符串字段.
+// minimum 等数值关键字对字符串不生效, 各字段含义与之相同. 无法解析为数值的字符串不满足 x-decimal.
+type DecimalBounds struct {
+	Minimum          *decimal.Decimal `json:"minimum,omitempty"`
+	Maximum          *decimal.Decimal `json:"maximum,omitempty"`
+	ExclusiveMinimum *decimal.Decimal `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *decimal.Decimal `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *decimal.Decimal `json:"multipleOf,omitempty"`
+}
+
+// MarshalJSON implement json.Marshaler
+func (b *DecimalBounds) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Minimum          json.RawMessage `json:"minimum,omitempty"`
+		Maximum          json.RawMessage `json:"maximum,omitempty"`
+		ExclusiveMinimum json.RawMessage `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum json.RawMessage `json:"exclusiveMaximum,omitempty"`
+		MultipleOf       json.RawMessage `json:"multipleOf,omitempty"`
+	}{
+		Minimum:          jsonNumber(b.Minimum),
+		Maximum:          jsonNumber(b.Maximum),
+		ExclusiveMinimum: jsonNumber(b.ExclusiveMinimum),
+		ExclusiveMaximum: jsonNumber(b.ExclusiveMaximum),
+		MultipleOf:       jsonNumber(b.MultipleOf),
+	})
+}
+
+// check 校验 val, 错误标签为 prefix 加上 gte, lte, gt, lt.
+func (b *DecimalBounds) check(val decimal.Decimal, prefix string, addErr func(tag, param string)) {
+	if b.Minimum != nil && val.LessThan(*b.Minimum) {
+		addErr(prefix+"gte", b.Minimum.String())
+	}
+	if b.Maximum != nil && val.GreaterThan(*b.Maximum) {
+		addErr(prefix+"lte", b.Maximum.String())
+	}
+	if b.ExclusiveMinimum != nil && val.LessThanOrEqual(*b.ExclusiveMinimum) {
+		addErr(prefix+"gt", b.ExclusiveMinimum.String())
+	}
+	if b.ExclusiveMaximum != nil && val.GreaterThanOrEqual(*b.ExclusiveMaximum) {
+		addErr(prefix+"lt", b.ExclusiveMaximum.String())
+	}
+	if b.MultipleOf != nil && !val.Mod(*b.MultipleOf).IsZero() {
+		addErr("decimal_multiple_of", b.MultipleOf.String())
+	}
+}
+
+func jsonNumber(d *decimal.Decimal) json.RawMessage {
+	if d == nil {
+		return nil
+	}
+	return json.RawMessage(d.String())
+}
+
+// SchemaOption schema option
+type SchemaOption func(*SchemaValidator)
+
+// WithSchemaFormat 添加自定义 format, 校验失败时错误的标签为 format 名.
+func WithSchemaFormat(name string, fn func(string) bool) SchemaOption {
+	return func(sv *SchemaValidator) {
+		sv.formats[name] = fn
+	}
+}
+
+// SchemaValidator 使用 JSON Schema 校验 map[string]any 或 json.
+// 校验错误为 validator.ValidationErrors, 与结构体校验的错误格式相同, 可以使用 TranslateErrors 翻译,
+// 命名空间以 $ 为根, 如 $.items[0].price.
+type SchemaValidator struct {
+	root    *Schema
+	formats map[string]func(string) bool
+	valid   *validator.Validate
+}
+
+// CompileSchema 编译 JSON Schema
+func CompileSchema(data []byte, opts ...SchemaOption) (*SchemaValidator, error) {
+	root := &Schema{}
+	if err := json.Unmarshal(data, root); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSchemaInvalid, err)
+	}
+	sv := &SchemaValidator{
+		root:    root,
+		formats: make(map[string]func(string) bool),
+		valid:   validator.New(),
+	}
+	for _, opt := range opts {
+		opt(sv)
+	}
+	if err := sv.compile(root); err != nil {
+		return nil, err
+	}
+	if err := checkRefCycles(root); err != nil {
+		return nil, err
+	}
+	return sv, nil
+}
+
+func (sv *SchemaValidator) compile(s *Schema) error {
+	if s == nil || s.boolean != nil {
+		return nil
+	}
+	if s.Ref != "" {
+		ref, err := sv.resolve(s.Ref)
+		if err != nil {
+			return err
+		}
+		s.ref = ref
+	}
+	if s.Pattern != "" {
+		rx, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%w: pattern %q: %v", ErrSchemaInvalid, s.Pattern, err)
+		}
+		s.pattern = rx
+	}
+	for _, raw := range s.Enum {
+		v, err := decodeJSONValue(raw)
+		if err != nil {
+			return fmt.Errorf("%w: enum: %v", ErrSchemaInvalid, err)
+		}
+		s.enum = append(s.enum, v)
+	}
+	if s.Const != nil {
+		v, err := decodeJSONValue(s.Const)
+		if err != nil {
+			return fmt.Errorf("%w: const: %v", ErrSchemaInvalid, err)
+		}
+		s.konst = v
+	}
+	if s.MultipleOf != nil && !s.MultipleOf.IsPositive() {
+		return fmt.Errorf("%w: multipleOf must be greater than 0", ErrSchemaInvalid)
+	}
+	if s.XDecimal != nil && s.XDecimal.MultipleOf != nil && !s.XDecimal.MultipleOf.IsPositive() {
+		return fmt.Errorf("%w: x-decimal: multipleOf must be greater than 0", ErrSchemaInvalid)
+	}
+
+	for _, c := range s.children() {
+		if err := sv.compile(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// children 子 schema
+func (s *Schema) children() []*Schema {
+	children := make([]*Schema, 0, len(s.Defs)+len(s.Properties)+len(s.AllOf)+len(s.AnyOf)+len(s.OneOf)+3)
+	for _, c := range s.Defs {
+		children = append(children, c)
+	}
+	for _, c := range s.Properties {
+		children = append(children, c)
+	}
+	children = append(children, s.AllOf...)
+	children = append(children, s.AnyOf...)
+	children = append(children, s.OneOf...)
+	return append(children, s.Not, s.AdditionalProperties, s.Items)
+}
+
+// inPlace 作用于同一个值的子 schema: $ref, allOf, anyOf, oneOf, not
+func (s *Schema) inPlace() []*Schema {
+	children := make([]*Schema, 0, len(s.AllOf)+len(s.AnyOf)+len(s.OneOf)+2)
+	children = append(children, s.ref, s.Not)
+	children = append(children, s.AllOf...)
+	children = append(children, s.AnyOf...)
+	return append(children, s.OneOf...)
+}
+
+// checkRefCycles 检查只经过 $ref, allOf, anyOf, oneOf, not 的循环引用,
+// 如 {"$defs": {"a": {"$ref": "#/$defs/a"}}}, 这样的 schema 校验时会无限递归.
+// 经过 properties, items 等关键字的递归引用是合法的, 每次递归都会进入更深一层的值.
+func checkRefCycles(root *Schema) error {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[*Schema]int)
+	var visit func(s *Schema) error
+	visit = func(s *Schema) error {
+		if s == nil || s.boolean != nil {
+			return nil
+		}
+		switch state[s] {
+		case visiting:
+			return fmt.Errorf("%w: circular reference", ErrSchemaRef)
+		case visited:
+			return nil
+		}
+		state[s] = visiting
+		for _, c := range s.inPlace() {
+			if err := visit(c); err != nil {
+				return err
+			}
+		}
+		state[s] = visited
+		return nil
+	}
+
+	stack := []*Schema{root}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if s == nil || s.boolean != nil {
+			continue
+		}
+		if err := visit(s); err != nil {
+			return err
+		}
+		stack = append(stack, s.children()...)
+	}
+	return nil
+}
+
+// resolve 解析本文档内的 $ref, 如 #, #/$defs/address
+func (sv *SchemaValidator) resolve(ref string) (*Schema, error) {
+	if ref == "#" {
+		return sv.root, nil
+	}
+	name := strings.TrimPrefix(ref, "#/$defs/")
+	if name == ref || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("%w: %q", ErrSchemaRef, ref)
+	}
+	s, ok := sv.root.Defs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrSchemaRef, ref)
+	}
+	return s, nil
+}
+
+// Validate 校验 v, v 通常为 map[string]any, 会先序列化为 json 再校验.
+func (sv *SchemaValidator) Validate(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return sv.ValidateJSON(data)
+}
+
+// ValidateJSON 校验 json
+func (sv *SchemaValidator) ValidateJSON(data []byte) error {
+	v, err := decodeJSONValue(data)
+	if err != nil {
+		return err
+	}
+	errs := sv.validate(sv.root, v, schemaRoot, schemaRoot)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (sv *SchemaValidator) validate(s *Schema, v any, ns, field string) validator.ValidationErrors {
+	if s == nil {
+		return nil
+	}
+	if s.boolean != nil {
+		if *s.boolean {
+			return nil
+		}
+		return validator.ValidationErrors{newSchemaError("not", ns, field, "", v)}
+	}
+	if s.ref != nil {
+		// 2020-12 中 $ref 与其它关键字同时生效
+		if errs := sv.validate(s.ref, v, ns, field); len(errs) > 0 {
+			return errs
+		}
+	}
+	if len(s.Type) > 0 && !matchSchemaType(s.Type, v) {
+		return validator.ValidationErrors{newSchemaError("type", ns, field, strings.Join(s.Type, " "), v)}
+	}
+
+	var errs validator.ValidationErrors
+	addErr := func(tag, param string) {
+		errs = append(errs, newSchemaError(tag, ns, field, param, v))
+	}
+
+	if s.enum != nil && !containsJSONValue(s.enum, v) {
+		params := make([]string, 0, len(s.Enum))
+		for _, raw := range s.Enum {
+			params = append(params, string(raw))
+		}
+		addErr("oneof", strings.Join(params, " "))
+	}
+	if s.Const != nil && !equalJSONValue(s.konst, v) {
+		addErr("eq", string(s.Const))
+	}
+
+	switch val := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(val)
+		if s.MinLength != nil && n < *s.MinLength {
+			addErr("min", strconv.Itoa(*s.MinLength))
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			addErr("max", strconv.Itoa(*s.MaxLength))
+		}
+		if s.pattern != nil && !s.pattern.MatchString(val) {
+			addErr("pattern", s.Pattern)
+		}
+		if s.Format != "" {
+			if tag, param, ok := sv.checkFormat(s.Format, val); !ok {
+				addErr(tag, param)
+			}
+		}
+		if s.XDecimal != nil {
+			if d, err := decimal.NewFromString(val); err == nil {
+				s.XDecimal.check(d, "decimal_", addErr)
+			} else if s.Format != "decimal" {
+				addErr("decimal", "")
+			}
+		}
+	case decimal.Decimal:
+		bounds := DecimalBounds{
+			Minimum:          s.Minimum,
+			Maximum:          s.Maximum,
+			ExclusiveMinimum: s.ExclusiveMinimum,
+			ExclusiveMaximum: s.ExclusiveMaximum,
+			MultipleOf:       s.MultipleOf,
+		}
+		bounds.check(val, "", addErr)
+		if s.Format != "" {
+			if tag, param, ok := sv.checkFormat(s.Format, val.String()); !ok {
+				addErr(tag, param)
+			}
+		}
+	case []any:
+		if s.MinItems != nil && len(val) < *s.MinItems {
+			addErr("min", strconv.Itoa(*s.MinItems))
+		}
+		if s.MaxItems != nil && len(val) > *s.MaxItems {
+			addErr("max", strconv.Itoa(*s.MaxItems))
+		}
+		if s.UniqueItems && !uniqueJSONValues(val) {
+			addErr("unique", "")
+		}
+		if s.Items != nil {
+			for i, item := range val {
+				name := field + "[" + strconv.Itoa(i) + "]"
+				errs = append(errs, sv.validate(s.Items, item, ns+"["+strconv.Itoa(i)+"]", name)...)
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				errs = append(errs, newSchemaError("required", ns+"."+name, name, "", nil))
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if ps, ok := s.Properties[k]; ok {
+				errs = append(errs, sv.validate(ps, val[k], ns+"."+k, k)...)
+			} else if s.AdditionalProperties != nil {
+				if s.AdditionalProperties.boolean != nil && !*s.AdditionalProperties.boolean {
+					errs = append(errs, newSchemaError("additional_properties", ns+"."+k, k, "", val[k]))
+				} else {
+					errs = append(errs, sv.validate(s.AdditionalProperties, val[k], ns+"."+k, k)...)
+				}
+			}
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		errs = append(errs, sv.validate(sub, v, ns, field)...)
+	}
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, sub := range s.AnyOf {
+			if len(sv.validate(sub, v, ns, field)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			addErr("any_of", "")
+		}
+	}
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, sub := range s.OneOf {
+			if len(sv.validate(sub, v, ns, field)) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			addErr("one_of", "")
+		}
+	}
+	if s.Not != nil && len(sv.validate(s.Not, v, ns, field)) == 0 {
+		addErr("not", "")
+	}
+	return errs
+}
+
+// checkFormat 校验 format, 返回失败时错误的标签和参数, 未知的 format 视为通过.
+func (sv *SchemaValidator) checkFormat(format, s string) (tag, param string, ok bool) {
+	if fn, exist := sv.formats[format]; exist {
+		return format, "", fn(s)
+	}
+	if fn, exist := schemaFormats[format]; exist {
+		return format, "", fn(s)
+	}
+	if vtag, exist := standardFormats[format]; exist {
+		tag, param, _ = strings.Cut(vtag, "=")
+		return tag, param, sv.valid.Var(s, vtag) == nil
+	}
+	return "", "", true
+}
+
+func matchSchemaType(types SchemaType, v any) bool {
+	for _, t := range types {
+		switch t {
+		case "null":
+			if v == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := v.(bool); ok {
+				return true
+			}
+		case "string":
+			if _, ok := v.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := v.(decimal.Decimal); ok {
+				return true
+			}
+		case "integer":
+			if d, ok := v.(decimal.Decimal); ok && d.Equal(d.Truncate(0)) {
+				return true
+			}
+		case "array":
+			if _, ok := v.([]any); ok {
+				return true
+			}
+		case "object":
+			if _, ok := v.(map[string]any); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// decodeJSONValue 解码 json, 数值解码为 decimal.Decimal 以便精确比较.
+func decodeJSONValue(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("schema: unexpected data after json value")
+	}
+	return normalizeJSONValue(v)
+}
+
+func normalizeJSONValue(v any) (any, error) {
+	switch val := v.(type) {
+	case json.Number:
+		return decimal.NewFromString(val.String())
+	case []any:
+		for i, item := range val {
+			n, err := normalizeJSONValue(item)
+			if err != nil {
+				return nil, err
+			}
+			val[i] = n
+		}
+	case map[string]any:
+		for k, item := range val {
+			n, err := normalizeJSONValue(item)
+			if err != nil {
+				return nil, err
+			}
+			val[k] = n
+		}
+	}
+	return v, nil
+}
+
+// equalJSONValue 比较两个 json 值, 数值按大小比较, 如 1 与 1.0 相等.
+func equalJSONValue(a, b any) bool {
+	switch av := a.(type) {
+	case decimal.Decimal:
+		bv, ok := b.(decimal.Decimal)
+		return ok && av.Equal(bv)
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equalJSONValue(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, item := range av {
+			other, exist := bv[k]
+			if !exist || !equalJSONValue(item, other) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func containsJSONValue(values []any, v any) bool {
+	for _, item := range values {
+		if equalJSONValue(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueJSONValues(values []any) bool {
+	for i := range values {
+		for j := i + 1; j < len(values); j++ {
+			if equalJSONValue(values[i], values[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// schemaError 实现 validator.FieldError
+type schemaError struct {
+	tag   string
+	ns    string
+	field string
+	param string
+	value any
+}
+
+var _ validator.FieldError = (*schemaError)(nil)
+
+func newSchemaError(tag, ns, field, param string, value any) *schemaError {
+	return &schemaError{tag: tag, ns: ns, field: field, param: param, value: value}
+}
+
+func (e *schemaError) Tag() string             { return e.tag }
+func (e *schemaError) ActualTag() string       { return e.tag }
+func (e *schemaError) Namespace() string       { return e.ns }
+func (e *schemaError) StructNamespace() string { return e.ns }
+func (e *schemaError) Field() string           { return e.field }
+func (e *schemaError) StructField() string     { return e.field }
+func (e *schemaError) Param() string           { return e.param }
+
+// Value 出错的值, 数值为 decimal.Decimal, 缺失的字段为 nil.
+func (e *schemaError) Value() any { return e.value }
+
+// Kind json 值对应的 reflect.Kind: 字符串 String, 数值 Float64, 数组 Slice, 对象 Map
+func (e *schemaError) Kind() reflect.Kind {
+	switch e.value.(type) {
+	case string:
+		return reflect.String
+	case bool:
+		return reflect.Bool
+	case decimal.Decimal:
+		return reflect.Float64
+	case []any:
+		return reflect.Slice
+	case map[string]any:
+		return reflect.Map
+	default:
+		return reflect.Invalid
+	}
+}
+
+func (e *schemaError) Type() reflect.Type { return reflect.TypeOf(e.value) }
+
+// Translate 翻译错误, json schema 关键字使用 schemaMessages, 其它依次查找 标签, 标签-string/number/items 的翻译.
+func (e *schemaError) Translate(trans ut.Translator) string {
+	if trans == nil {
+		return e.Error()
+	}
+	if msg, ok := schemaMessages[messageLocale(trans)][e.tag]; ok {
+		return strings.NewReplacer("{0}", e.field, "{1}", e.param).Replace(msg)
+	}
+	keys := []string{e.tag}
+	switch e.Kind() {
+	case reflect.String:
+		keys = append(keys, e.tag+"-string")
+	case reflect.Float64:
+		keys = append(keys, e.tag+"-number")
+	case reflect.Slice, reflect.Map:
+		keys = append(keys, e.tag+"-items")
+	}
+	for _, key := range keys {
+		if msg, err := trans.T(key, e.field, e.param); err == nil {
+			return msg
+		}
+	}
+	return e.Error()
+}
+
+func (e *schemaError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag", e.ns, e.field, e.tag)
+}
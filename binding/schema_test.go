@@ -0,0 +1,198 @@
+package binding
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+)
+
+const orderSchema = `{
+  "type": "object",
+  "required": ["mobile", "amount", "items"],
+  "additionalProperties": false,
+  "properties": {
+    "mobile": {"type": "string", "format": "mobile"},
+    "amount": {"type": "string", "format": "decimal"},
+    "discount": {"type": "string", "x-decimal": {"minimum": 0, "exclusiveMaximum": 1}},
+    "count": {"type": "string", "format": "number_gt0"},
+    "email": {"type": "string", "format": "email"},
+    "status": {"enum": ["pending", "paid", 1]},
+    "version": {"const": 2},
+    "remark": {"type": ["string", "null"], "maxLength": 5},
+    "code": {"type": "string", "pattern": "^[A-Z]{3}$"},
+    "items": {
+      "type": "array",
+      "minItems": 1,
+      "uniqueItems": true,
+      "items": {"$ref": "#/$defs/item"}
+    },
+    "meta": {"type": "object", "additionalProperties": {"type": "integer"}},
+    "contact": {"anyOf": [{"type": "string", "format": "mobile"}, {"type": "string", "format": "email"}]},
+    "choice": {"oneOf": [{"type": "integer"}, {"type": "number", "minimum": 0}]},
+    "not_empty": {"not": {"const": ""}}
+  },
+  "$defs": {
+    "item": {
+      "type": "object",
+      "required": ["price"],
+      "properties": {
+        "price": {"type": "number", "exclusiveMinimum": 0, "maximum": 100, "multipleOf": 0.01},
+        "qty": {"type": "integer", "minimum": 1}
+      }
+    }
+  }
+}`
+
+func TestSchemaValidator(t *testing.T) {
+	sv, err := CompileSchema([]byte(orderSchema))
+	require.NoError(t, err)
+
+	ok := map[string]any{
+		"mobile":    "13800138000",
+		"amount":    "9.99",
+		"discount":  "0.5",
+		"count":     "3",
+		"email":     "a@b.com",
+		"status":    1.0,
+		"version":   2,
+		"remark":    nil,
+		"code":      "ABC",
+		"items":     []any{map[string]any{"price": 0.1, "qty": 1}, map[string]any{"price": 99.99}},
+		"meta":      map[string]any{"a": 1},
+		"contact":   "a@b.com",
+		"choice":    -1,
+		"not_empty": "x",
+	}
+	require.NoError(t, sv.Validate(ok))
+	require.NoError(t, sv.ValidateJSON([]byte(`{"mobile":"13800138000","amount":"1","items":[{"price":1.00}]}`)))
+
+	tests := []struct {
+		name  string
+		key   string
+		value any
+		ns    string
+		tag   string
+	}{
+		{"format mobile", "mobile", "123", "$.mobile", "mobile"},
+		{"format decimal", "amount", "1.1.1", "$.amount", "decimal"},
+		{"format number_gt0", "count", "0", "$.count", "number_gt0"},
+		{"x-decimal minimum", "discount", "-5", "$.discount", "decimal_gte"},
+		{"x-decimal exclusive maximum", "discount", "1", "$.discount", "decimal_lt"},
+		{"x-decimal not a number", "discount", "abc", "$.discount", "decimal"},
+		{"format email", "email", "a@", "$.email", "email"},
+		{"enum", "status", "done", "$.status", "oneof"},
+		{"const", "version", 3, "$.version", "eq"},
+		{"type", "remark", 1, "$.remark", "type"},
+		{"max length", "remark", "abcdef", "$.remark", "max"},
+		{"pattern", "code", "abc", "$.code", "pattern"},
+		{"min items", "items", []any{}, "$.items", "min"},
+		{"unique items", "items", []any{map[string]any{"price": 1}, map[string]any{"price": 1.0}}, "$.items", "unique"},
+		{"required", "items", []any{map[string]any{}}, "$.items[0].price", "required"},
+		{"exclusive minimum", "items", []any{map[string]any{"price": 0}}, "$.items[0].price", "gt"},
+		{"maximum", "items", []any{map[string]any{"price": 100.01}}, "$.items[0].price", "lte"},
+		{"multiple of", "items", []any{map[string]any{"price": 0.001}}, "$.items[0].price", "decimal_multiple_of"},
+		{"integer", "items", []any{map[string]any{"price": 1, "qty": 1.5}}, "$.items[0].qty", "type"},
+		{"additional schema", "meta", map[string]any{"a": "x"}, "$.meta.a", "type"},
+		{"additional false", "unknown", 1, "$.unknown", "additional_properties"},
+		{"any of", "contact", "x", "$.contact", "any_of"},
+		{"one of", "choice", 1, "$.choice", "one_of"},
+		{"not", "not_empty", "", "$.not_empty", "not"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := make(map[string]any, len(ok))
+			for k, v := range ok {
+				m[k] = v
+			}
+			m[tt.key] = tt.value
+			err := sv.Validate(m)
+			require.Error(t, err)
+			errs, isErrs := err.(validator.ValidationErrors)
+			require.True(t, isErrs)
+			require.Len(t, errs, 1, err.Error())
+			require.Equal(t, tt.ns, errs[0].Namespace())
+			require.Equal(t, tt.tag, errs[0].Tag())
+		})
+	}
+
+	err = sv.ValidateJSON([]byte(`{}`))
+	require.Len(t, err.(validator.ValidationErrors), 3)
+	require.Error(t, sv.ValidateJSON([]byte(`{`)))
+	require.Error(t, sv.ValidateJSON([]byte(`{} {}`)))
+}
+
+func TestSchemaTranslate(t *testing.T) {
+	sv, err := CompileSchema([]byte(orderSchema), WithSchemaFormat("mobile", func(s string) bool { return s == "x" }))
+	require.NoError(t, err)
+
+	valid := newTestValidate(t)
+	zh, err := NewTranslator(valid, LocaleZh)
+	require.NoError(t, err)
+	en, err := NewTranslator(valid, LocaleEn)
+	require.NoError(t, err)
+
+	err = sv.ValidateJSON([]byte(`{"mobile":"13800138000","amount":"1","items":[{"price":-1}],"remark":"abcdef","x":1}`))
+	require.Equal(t, map[string]string{
+		"mobile":         "mobile必须是有效的手机号",
+		"items[0].price": "price必须大于0",
+		"remark":         "remark长度不能超过5",
+		"x":              "x是未定义的字段",
+	}, TranslateErrors(err, zh))
+	require.Equal(t, "x is not an allowed field", TranslateErrors(err, en)["x"])
+
+	// 同名的校验标签使用自己的翻译
+	require.NoError(t, valid.RegisterValidation("type", func(fl validator.FieldLevel) bool { return false }))
+	zh, err = NewTranslator(valid, LocaleZh, WithMessages(map[string]string{"type": "{0}类型错误"}))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"Kind": "Kind类型错误"}, TranslateErrors(valid.Struct(&struct {
+		Kind string `validate:"type"`
+	}{}), zh))
+	err = sv.ValidateJSON([]byte(`{"mobile":1,"amount":"1","items":[]}`))
+	require.Equal(t, "mobile必须是string类型", TranslateErrors(err, zh)["mobile"])
+}
+
+func TestCompileSchema(t *testing.T) {
+	for _, s := range []string{
+		`[`,
+		`{"pattern": "("}`,
+		`{"$ref": "#/$defs/missing"}`,
+		`{"$ref": "http://example.com/schema"}`,
+		`{"multipleOf": 0}`,
+		`{"x-decimal": {"multipleOf": 0}}`,
+		`{"type": 1}`,
+	} {
+		_, err := CompileSchema([]byte(s))
+		require.Error(t, err, s)
+	}
+
+	// 不经过 properties, items 等关键字的循环引用
+	for _, s := range []string{
+		`{"$ref": "#"}`,
+		`{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`,
+		`{"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"$ref": "#/$defs/a"}}}`,
+		`{"$defs": {"a": {"allOf": [{"$ref": "#/$defs/a"}]}}}`,
+		`{"properties": {"x": {"type": "string"}}, "anyOf": [{"not": {"$ref": "#"}}]}`,
+	} {
+		_, err := CompileSchema([]byte(s))
+		require.ErrorIs(t, err, ErrSchemaRef, s)
+	}
+
+	// 递归引用
+	sv, err := CompileSchema([]byte(`{"type": "object", "properties": {"child": {"$ref": "#"}, "name": {"type": "string"}}}`))
+	require.NoError(t, err)
+	require.NoError(t, sv.ValidateJSON([]byte(`{"child": {"child": {"name": "x"}}}`)))
+	require.Error(t, sv.ValidateJSON([]byte(`{"child": {"child": {"name": 1}}}`)))
+
+	// boolean schema
+	sv, err = CompileSchema([]byte(`false`))
+	require.NoError(t, err)
+	require.Error(t, sv.ValidateJSON([]byte(`1`)))
+
+	var s Schema
+	require.NoError(t, json.Unmarshal([]byte(`{"type":"string","additionalProperties":false}`), &s))
+	b, err := json.Marshal(&s)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"string","additionalProperties":false}`, string(b))
+}
@@ -0,0 +1,289 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+	"go.uber.org/multierr"
+)
+
+// locale defined
+const (
+	LocaleZh = "zh"
+	LocaleEn = "en"
+)
+
+// messages 自定义标签的错误信息模板, {0} 为字段名, {1} 为标签参数.
+var messages = map[string]map[string]string{
+	LocaleZh: {
+		"mobile":              "{0}必须是有效的手机号",
+		"phone":               "{0}必须是有效的电话号码",
+		"e164":                "{0}必须是有效的E.164格式电话号码",
+		"idcard":              "{0}必须是有效的身份证号码",
+		"hk_macau_permit":     "{0}必须是有效的港澳居民来往内地通行证号码",
+		"taiwan_permit":       "{0}必须是有效的台湾居民来往大陆通行证号码",
+		"uscc":                "{0}必须是有效的统一社会信用代码",
+		"bank_card":           "{0}必须是有效的银行卡号",
+		"bank_card_bin":       "{0}必须是已收录发卡行的银行卡号",
+		"iban":                "{0}必须是有效的IBAN",
+		"swift_bic":           "{0}必须是有效的SWIFT/BIC代码",
+		"cn_postal_code":      "{0}必须是有效的邮政编码",
+		"license_plate":       "{0}必须是有效的车牌号",
+		"new_energy_plate":    "{0}必须是有效的新能源车牌号",
+		"region_code":         "{0}必须是有效的行政区划代码",
+		"region_in":           "{0}必须隶属于{1}",
+		"decimal":             "{0}必须是有效的数值",
+		"decimal_gt":          "{0}必须大于{1}",
+		"decimal_gte":         "{0}必须大于或等于{1}",
+		"decimal_lt":          "{0}必须小于{1}",
+		"decimal_lte":         "{0}必须小于或等于{1}",
+		"decimal_min":         "{0}必须大于或等于{1}",
+		"decimal_max":         "{0}必须小于或等于{1}",
+		"decimal_scale":       "{0}最多只能有{1}位小数",
+		"decimal_precision":   "{0}最多只能有{1}位有效数字",
+		"decimal_between":     "{0}必须在{1}之间",
+		"decimal_multiple_of": "{0}必须是{1}的整数倍",
+		"decimal_gtfield":     "{0}必须大于{1}",
+		"decimal_gtefield":    "{0}必须大于或等于{1}",
+		"decimal_ltfield":     "{0}必须小于{1}",
+		"decimal_ltefield":    "{0}必须小于或等于{1}",
+		"decimal_gtcsfield":   "{0}必须大于{1}",
+		"decimal_gtecsfield":  "{0}必须大于或等于{1}",
+		"decimal_ltcsfield":   "{0}必须小于{1}",
+		"decimal_ltecsfield":  "{0}必须小于或等于{1}",
+		"number_gt0":          "{0}必须是大于0的整数",
+		"number_gte0":         "{0}必须是大于或等于0的整数",
+		"number_range":        "{0}必须是{1}之间的整数",
+		"time_rfc3339":        "{0}必须是有效的RFC 3339格式时间",
+		"time_unix":           "{0}必须是有效的unix时间戳",
+		"time_date":           "{0}必须是有效的日期",
+		"time_after":          "{0}必须晚于{1}",
+		"time_before":         "{0}必须早于{1}",
+		"time_min":            "{0}不能早于{1}",
+		"time_max":            "{0}不能晚于{1}",
+		"time_within":         "{0}必须在当前时间的{1}以内",
+		"time_gtfield":        "{0}必须晚于{1}",
+		"time_gtefield":       "{0}不能早于{1}",
+		"time_ltfield":        "{0}必须早于{1}",
+		"time_ltefield":       "{0}不能晚于{1}",
+		"time_of_day":         "{0}必须在{1}之间",
+		"time_weekday":        "{0}必须是星期{1}",
+	},
+	LocaleEn: {
+		"mobile":              "{0} must be a valid mobile number",
+		"phone":               "{0} must be a valid phone number",
+		"e164":                "{0} must be a valid E.164 formatted phone number",
+		"idcard":              "{0} must be a valid resident identity card number",
+		"hk_macau_permit":     "{0} must be a valid Hong Kong and Macau permit number",
+		"taiwan_permit":       "{0} must be a valid Taiwan permit number",
+		"uscc":                "{0} must be a valid unified social credit code",
+		"bank_card":           "{0} must be a valid bank card number",
+		"bank_card_bin":       "{0} must be a bank card number with a known issuer",
+		"iban":                "{0} must be a valid IBAN",
+		"swift_bic":           "{0} must be a valid SWIFT/BIC code",
+		"cn_postal_code":      "{0} must be a valid postal code",
+		"license_plate":       "{0} must be a valid license plate number",
+		"new_energy_plate":    "{0} must be a valid new energy license plate number",
+		"region_code":         "{0} must be a valid administrative region code",
+		"region_in":           "{0} must be within {1}",
+		"decimal":             "{0} must be a valid decimal",
+		"decimal_gt":          "{0} must be greater than {1}",
+		"decimal_gte":         "{0} must be {1} or greater",
+		"decimal_lt":          "{0} must be less than {1}",
+		"decimal_lte":         "{0} must be {1} or less",
+		"decimal_min":         "{0} must be {1} or greater",
+		"decimal_max":         "{0} must be {1} or less",
+		"decimal_scale":       "{0} must have at most {1} decimal places",
+		"decimal_precision":   "{0} must have at most {1} significant digits",
+		"decimal_between":     "{0} must be between {1}",
+		"decimal_multiple_of": "{0} must be a multiple of {1}",
+		"decimal_gtfield":     "{0} must be greater than {1}",
+		"decimal_gtefield":    "{0} must be greater than or equal to {1}",
+		"decimal_ltfield":     "{0} must be less than {1}",
+		"decimal_ltefield":    "{0} must be less than or equal to {1}",
+		"decimal_gtcsfield":   "{0} must be greater than {1}",
+		"decimal_gtecsfield":  "{0} must be greater than or equal to {1}",
+		"decimal_ltcsfield":   "{0} must be less than {1}",
+		"decimal_ltecsfield":  "{0} must be less than or equal to {1}",
+		"number_gt0":          "{0} must be an integer greater than 0",
+		"number_gte0":         "{0} must be an integer greater than or equal to 0",
+		"number_range":        "{0} must be an integer between {1}",
+		"time_rfc3339":        "{0} must be a valid RFC 3339 time",
+		"time_unix":           "{0} must be a valid unix timestamp",
+		"time_date":           "{0} must be a valid date",
+		"time_after":          "{0} must be after {1}",
+		"time_before":         "{0} must be before {1}",
+		"time_min":            "{0} must not be before {1}",
+		"time_max":            "{0} must not be after {1}",
+		"time_within":         "{0} must be within {1} of the current time",
+		"time_gtfield":        "{0} must be after {1}",
+		"time_gtefield":       "{0} must not be before {1}",
+		"time_ltfield":        "{0} must be before {1}",
+		"time_ltefield":       "{0} must not be after {1}",
+		"time_of_day":         "{0} must be between {1}",
+		"time_weekday":        "{0} must be on weekday {1}",
+	},
+}
+
+// schemaMessages json schema 关键字的错误信息模板, 只用于 SchemaValidator 的错误, 不注册为标签的翻译, 以免与同名的校验标签冲突.
+var schemaMessages = map[string]map[string]string{
+	LocaleZh: {
+		"type":                  "{0}必须是{1}类型",
+		"pattern":               "{0}格式不正确",
+		"additional_properties": "{0}是未定义的字段",
+		"any_of":                "{0}必须至少满足一个条件",
+		"one_of":                "{0}必须只满足一个条件",
+		"not":                   "{0}不能满足该条件",
+	},
+	LocaleEn: {
+		"type":                  "{0} must be of type {1}",
+		"pattern":               "{0} does not match the required pattern",
+		"additional_properties": "{0} is not an allowed field",
+		"any_of":                "{0} must match at least one schema",
+		"one_of":                "{0} must match exactly one schema",
+		"not":                   "{0} must not match the schema",
+	},
+}
+
+// TranslationOption 翻译选项
+type TranslationOption func(*translationConfig)
+
+type translationConfig struct {
+	prefix   string
+	messages map[string]string
+}
+
+// WithTranslationTagPrefix 自定义校验标签的前缀, 与 RegisterValidation 的 WithTagPrefix 相同.
+func WithTranslationTagPrefix(prefix string) TranslationOption {
+	return func(c *translationConfig) {
+		c.prefix = prefix
+	}
+}
+
+// WithMessages 覆盖错误信息模板, key 为标签名, {0} 为字段名, {1} 为标签参数.
+// 可以覆盖自定义标签(使用前缀时包含前缀), 也可以覆盖 validator 内置标签.
+func WithMessages(mp map[string]string) TranslationOption {
+	return func(c *translationConfig) {
+		for tag, msg := range mp {
+			c.messages[tag] = msg
+		}
+	}
+}
+
+// NewTranslator 新建 locale(zh 或 en) 的翻译器, 并注册 validator 内置标签和自定义标签的翻译.
+func NewTranslator(valid *validator.Validate, locale string, opts ...TranslationOption) (ut.Translator, error) {
+	var err error
+	var trans ut.Translator
+
+	switch locale {
+	case LocaleZh:
+		trans, _ = ut.New(zh.New()).GetTranslator(LocaleZh)
+		err = zhTranslations.RegisterDefaultTranslations(valid, trans)
+	case LocaleEn:
+		trans, _ = ut.New(en.New()).GetTranslator(LocaleEn)
+		err = enTranslations.RegisterDefaultTranslations(valid, trans)
+	default:
+		return nil, fmt.Errorf("validator: unsupported locale %q", locale)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = RegisterTranslations(valid, trans, opts...); err != nil {
+		return nil, err
+	}
+	return trans, nil
+}
+
+// RegisterTranslations 注册自定义标签的翻译, 根据 trans.Locale() 选择 zh 或 en, 其它语言使用 en.
+func RegisterTranslations(valid *validator.Validate, trans ut.Translator, opts ...TranslationOption) error {
+	locale := messageLocale(trans)
+	c := &translationConfig{messages: make(map[string]string)}
+	for _, opt := range opts {
+		opt(c)
+	}
+	mp := make(map[string]string, len(messages[locale])+len(c.messages))
+	for tag, msg := range messages[locale] {
+		if _, ok := lookupValidation(tag); ok {
+			tag = c.prefix + tag
+		}
+		mp[tag] = msg
+	}
+	for tag, msg := range c.messages {
+		mp[tag] = msg
+	}
+
+	var err error
+	for tag, msg := range mp {
+		err = multierr.Append(err, valid.RegisterTranslation(tag, trans, registerTranslation(tag, msg), translate))
+	}
+	if err != nil {
+		return fmt.Errorf("validator: register translation failed, %w", err)
+	}
+	return nil
+}
+
+// TranslateErrors 将校验错误翻译为 字段 -> 错误信息.
+// 字段为去掉顶层结构体名的命名空间, 如 items[0].price, 使用 RegisterJSONTagName 后为 json 标签名.
+// err 不是 validator.ValidationErrors 时, 返回 nil.
+func TranslateErrors(err error, trans ut.Translator) map[string]string {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+	mp := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		mp[fieldPath(fe.Namespace())] = fe.Translate(trans)
+	}
+	return mp
+}
+
+// RegisterJSONTagName 使用 json 标签名作为错误中的字段名, json 标签为 "-" 的字段使用原字段名.
+func RegisterJSONTagName(valid *validator.Validate) {
+	valid.RegisterTagNameFunc(jsonTagName)
+}
+
+func jsonTagName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// fieldPath 去掉命名空间中的顶层结构体名
+func fieldPath(ns string) string {
+	if _, path, ok := strings.Cut(ns, "."); ok {
+		return path
+	}
+	return ns
+}
+
+// messageLocale 翻译器对应的错误信息语言, 其它语言使用 en.
+func messageLocale(trans ut.Translator) string {
+	if strings.HasPrefix(trans.Locale(), LocaleZh) {
+		return LocaleZh
+	}
+	return LocaleEn
+}
+
+func registerTranslation(tag, msg string) validator.RegisterTranslationsFunc {
+	return func(trans ut.Translator) error {
+		return trans.Add(tag, msg, true)
+	}
+}
+
+func translate(trans ut.Translator, fe validator.FieldError) string {
+	msg, err := trans.T(fe.Tag(), fe.Field(), fe.Param())
+	if err != nil {
+		return fe.Error()
+	}
+	return msg
+}
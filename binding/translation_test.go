@@ -0,0 +1,90 @@
+package binding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessages(t *testing.T) {
+	zhMessages, enMessages := messages[LocaleZh], messages[LocaleEn]
+	require.Equal(t, len(zhMessages), len(enMessages))
+	for tag := range zhMessages {
+		require.Contains(t, enMessages, tag)
+	}
+	for tag := range paramTags {
+		require.Contains(t, zhMessages, tag)
+	}
+	for _, v := range Validations() {
+		require.Contains(t, zhMessages, v.Tag)
+	}
+	require.Equal(t, zhMessages["decimal_gte"], zhMessages["decimal_min"])
+	require.Equal(t, zhMessages["decimal_lte"], zhMessages["decimal_max"])
+	require.Equal(t, enMessages["decimal_gte"], enMessages["decimal_min"])
+	require.Equal(t, enMessages["decimal_lte"], enMessages["decimal_max"])
+
+	require.Equal(t, len(schemaMessages[LocaleZh]), len(schemaMessages[LocaleEn]))
+	for tag := range schemaMessages[LocaleZh] {
+		require.Contains(t, schemaMessages[LocaleEn], tag)
+		require.NotContains(t, zhMessages, tag)
+	}
+}
+
+func TestTranslateErrors(t *testing.T) {
+	valid := newTestValidate(t)
+	RegisterJSONTagName(valid)
+
+	type Item struct {
+		Price string `json:"price" validate:"decimal_gt=0"`
+	}
+	type Order struct {
+		Mobile string `json:"mobile,omitempty" validate:"mobile"`
+		Amount string `json:"amount" validate:"decimal_between=0.01~100"`
+		Name   string `json:"-" validate:"required"`
+		Items  []Item `json:"items" validate:"dive"`
+	}
+	order := &Order{Mobile: "123", Amount: "0", Items: []Item{{Price: "0"}}}
+
+	zh, err := NewTranslator(valid, LocaleZh)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"mobile":         "mobile必须是有效的手机号",
+		"amount":         "amount必须在0.01~100之间",
+		"Name":           "Name为必填字段",
+		"items[0].price": "price必须大于0",
+	}, TranslateErrors(valid.Struct(order), zh))
+
+	en, err := NewTranslator(valid, LocaleEn, WithMessages(map[string]string{
+		"mobile":   "{0} is not a mobile",
+		"required": "{0} is required",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"mobile":         "mobile is not a mobile",
+		"amount":         "amount must be between 0.01~100",
+		"Name":           "Name is required",
+		"items[0].price": "price must be greater than 0",
+	}, TranslateErrors(valid.Struct(order), en))
+
+	require.Nil(t, TranslateErrors(errors.New("not validation errors"), en))
+	require.Nil(t, TranslateErrors(nil, en))
+
+	_, err = NewTranslator(validator.New(), "fr")
+	require.Error(t, err)
+}
+
+func TestTranslateErrorsWithPrefix(t *testing.T) {
+	valid := validator.New()
+	require.NoError(t, RegisterValidation(valid, WithTagPrefix("clip_")))
+
+	type User struct {
+		Mobile string `validate:"clip_mobile"`
+	}
+	zh, err := NewTranslator(valid, LocaleZh, WithTranslationTagPrefix("clip_"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"Mobile": "Mobile必须是有效的手机号",
+	}, TranslateErrors(valid.Struct(&User{Mobile: "123"}), zh))
+}
@@ -3,99 +3,479 @@ package binding
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/shopspring/decimal"
 	"go.uber.org/multierr"
 )
 
-func RegisterValidation(valid *validator.Validate) error {
-	valid.RegisterCustomTypeFunc(
-		func(field reflect.Value) any {
-			if valuer, ok := field.Interface().(decimal.Decimal); ok {
-				return valuer.String()
+// Validation 自定义校验标签
+type Validation struct {
+	// Tag 标签名, 使用 WithTagPrefix 时包含前缀
+	Tag string
+	// Description 标签说明, 可用于生成 API 文档
+	Description string
+	// Func 校验函数
+	Func validator.Func
+}
+
+// validations 所有自定义校验标签, 按注册顺序排列.
+var validations = []Validation{
+	{"mobile", "手机号, 默认为中国大陆手机号, 带参数时为指定地区的手机号, 如 mobile=HK", ValidIsMobile},
+	{"phone", "电话号码, 包括固话, 带参数时支持指定地区的国内格式, 如 phone=CN, 否则必须是国际格式", ValidIsPhone},
+	{"e164", "E.164 格式的电话号码, 如 +8613800138000", ValidIsE164},
+	{"idcard", "18 位居民身份证号码, 包括港澳台居民居住证", ValidIsIDCard},
+	{"hk_macau_permit", "港澳居民来往内地通行证号码", ValidIsHKMacauPermit},
+	{"taiwan_permit", "台湾居民来往大陆通行证号码", ValidIsTaiwanPermit},
+	{"uscc", "统一社会信用代码", ValidIsUSCC},
+	{"bank_card", "银联, Visa 或万事达卡号, 参数为以空格分隔的卡组织, 如 bank_card=unionpay visa", ValidIsBankCard},
+	{"bank_card_bin", "卡号前缀在当前加载的 BIN 表中的银行卡号", ValidIsBankCardInBins},
+	{"iban", "国际银行账号(IBAN)", ValidIsIBAN},
+	{"swift_bic", "SWIFT/BIC 代码", ValidIsSwiftBic},
+	{"cn_postal_code", "中国邮政编码", ValidIsCNPostalCode},
+	{"license_plate", "机动车号牌, 包括新能源号牌", ValidIsLicensePlate},
+	{"new_energy_plate", "新能源号牌", ValidIsNewEnergyPlate},
+	{"region_code", "行政区划代码(GB/T 2260), 参数为以空格分隔的级别, 如 region_code=city district", ValidIsRegionCode},
+	{"region_in", "行政区划代码隶属于另一字段的行政区划, 如 region_in=Province", ValidIsRegionIn},
+	{"decimal", "数值", ValidIsDecimal},
+	{"decimal_gt", "数值大于参数, 如 decimal_gt=0", ValidIsDecimalGt},
+	{"decimal_gte", "数值大于等于参数, 如 decimal_gte=0", ValidIsDecimalGte},
+	{"decimal_lt", "数值小于参数, 如 decimal_lt=100", ValidIsDecimalLt},
+	{"decimal_lte", "数值小于等于参数, 如 decimal_lte=100", ValidIsDecimalLte},
+	{"decimal_min", "数值大于等于参数, 同 decimal_gte", ValidDecimalMinOf},
+	{"decimal_max", "数值小于等于参数, 同 decimal_lte", ValidDecimalMaxOf},
+	{"decimal_scale", "数值小数位数不超过参数, 如 decimal_scale=2", ValidDecimalScale},
+	{"decimal_precision", "数值有效数字总位数不超过参数, 如 decimal_precision=18", ValidDecimalPrecision},
+	{"decimal_between", "数值在闭区间内, 如 decimal_between=0.01~100", ValidDecimalBetween},
+	{"decimal_multiple_of", "数值为参数的整数倍, 如 decimal_multiple_of=0.05", ValidDecimalMultipleOf},
+	{"decimal_gtfield", "数值大于同一结构体中的另一字段", ValidDecimalGtField},
+	{"decimal_gtefield", "数值大于等于同一结构体中的另一字段", ValidDecimalGteField},
+	{"decimal_ltfield", "数值小于同一结构体中的另一字段", ValidDecimalLtField},
+	{"decimal_ltefield", "数值小于等于同一结构体中的另一字段", ValidDecimalLteField},
+	{"decimal_gtcsfield", "数值大于顶层结构体中的字段", ValidDecimalGtCsField},
+	{"decimal_gtecsfield", "数值大于等于顶层结构体中的字段", ValidDecimalGteCsField},
+	{"decimal_ltcsfield", "数值小于顶层结构体中的字段", ValidDecimalLtCsField},
+	{"decimal_ltecsfield", "数值小于等于顶层结构体中的字段", ValidDecimalLteCsField},
+	{"number_gt0", "大于 0 的整数, 支持整型和字符串", ValidNumberGt0},
+	{"number_gte0", "大于等于 0 的整数, 支持整型和字符串", ValidNumberGte0},
+	{"time_rfc3339", "RFC 3339 格式的时间, 如 2006-01-02T15:04:05+08:00", ValidIsRFC3339},
+	{"time_unix", "unix 秒, 支持字符串和整型", ValidIsUnixSeconds},
+	{"time_date", "2006-01-02 格式的日期", ValidIsDate},
+	{"time_after", "时间晚于参数, 参数为 now, today, 可带偏移量, 如 time_after=now+1h, 或绝对时间", defaultTimeValidator.ValidAfter},
+	{"time_before", "时间早于参数, 参数同 time_after", defaultTimeValidator.ValidBefore},
+	{"time_min", "时间不早于参数, 参数同 time_after", defaultTimeValidator.ValidMin},
+	{"time_max", "时间不晚于参数, 参数同 time_after", defaultTimeValidator.ValidMax},
+	{"time_within", "时间与当前时间相差不超过参数, 如 time_within=720h", defaultTimeValidator.ValidWithin},
+	{"time_gtfield", "时间晚于同一结构体中的另一字段, 如 time_gtfield=StartAt", defaultTimeValidator.ValidGtField},
+	{"time_gtefield", "时间不早于同一结构体中的另一字段", defaultTimeValidator.ValidGteField},
+	{"time_ltfield", "时间早于同一结构体中的另一字段", defaultTimeValidator.ValidLtField},
+	{"time_ltefield", "时间不晚于同一结构体中的另一字段", defaultTimeValidator.ValidLteField},
+	{"time_of_day", "时刻在闭区间内, 如 time_of_day=09:00~18:00", defaultTimeValidator.ValidTimeOfDay},
+	{"time_weekday", "星期为参数之一, 如 time_weekday=mon tue wed thu fri", defaultTimeValidator.ValidWeekday},
+	{"number_range", "闭区间内的整数, 字符串可以超出 int64 范围, 如 number_range=1~18446744073709551615", ValidNumberRange},
+}
+
+// timeValidations 依赖时区和当前时间的标签, 使用 WithTimeValidator 时替换为其方法.
+var timeValidations = map[string]func(tv *TimeValidator) validator.Func{
+	"time_after":    func(tv *TimeValidator) validator.Func { return tv.ValidAfter },
+	"time_before":   func(tv *TimeValidator) validator.Func { return tv.ValidBefore },
+	"time_min":      func(tv *TimeValidator) validator.Func { return tv.ValidMin },
+	"time_max":      func(tv *TimeValidator) validator.Func { return tv.ValidMax },
+	"time_within":   func(tv *TimeValidator) validator.Func { return tv.ValidWithin },
+	"time_gtfield":  func(tv *TimeValidator) validator.Func { return tv.ValidGtField },
+	"time_gtefield": func(tv *TimeValidator) validator.Func { return tv.ValidGteField },
+	"time_ltfield":  func(tv *TimeValidator) validator.Func { return tv.ValidLtField },
+	"time_ltefield": func(tv *TimeValidator) validator.Func { return tv.ValidLteField },
+	"time_of_day":   func(tv *TimeValidator) validator.Func { return tv.ValidTimeOfDay },
+	"time_weekday":  func(tv *TimeValidator) validator.Func { return tv.ValidWeekday },
+}
+
+// paramTags 带参数的校验标签及其参数的解析函数, 标签名不含前缀.
+// 参数在解析时被缓存, 校验时参数错误会 panic, 见 panicBadParam.
+var paramTags = map[string]func(param string) error{
+	"decimal_gt":          checkDecimalParam,
+	"decimal_gte":         checkDecimalParam,
+	"decimal_lt":          checkDecimalParam,
+	"decimal_lte":         checkDecimalParam,
+	"decimal_min":         checkDecimalParam,
+	"decimal_max":         checkDecimalParam,
+	"decimal_scale":       checkDigitsParam,
+	"decimal_precision":   checkDigitsParam,
+	"decimal_between":     checkDecimalRangeParam,
+	"decimal_multiple_of": checkDecimalStepParam,
+	"number_range":        checkNumberRangeParam,
+	"time_after":          checkTimeBoundParam,
+	"time_before":         checkTimeBoundParam,
+	"time_min":            checkTimeBoundParam,
+	"time_max":            checkTimeBoundParam,
+	"time_within":         checkDurationParam,
+	"time_of_day":         checkClockRangeParam,
+	"time_weekday":        checkWeekdaysParam,
+}
+
+// ValidationOption 注册选项
+type ValidationOption func(*validationConfig)
+
+type validationConfig struct {
+	time     *TimeValidator
+	prefix   string
+	tags     []string
+	excludes map[string]struct{}
+	structs  []structValidation
+	tagName  string
+	requests []any
+}
+
+type structValidation struct {
+	fn    validator.StructLevelFunc
+	types []any
+}
+
+// WithValidationTags 仅注册指定的标签, 标签名不含前缀.
+func WithValidationTags(tags ...string) ValidationOption {
+	return func(c *validationConfig) {
+		c.tags = append(c.tags, tags...)
+	}
+}
+
+// WithoutValidationTags 不注册指定的标签, 标签名不含前缀, 用于避免覆盖已注册的同名标签.
+func WithoutValidationTags(tags ...string) ValidationOption {
+	return func(c *validationConfig) {
+		for _, tag := range tags {
+			c.excludes[tag] = struct{}{}
+		}
+	}
+}
+
+// WithTagPrefix 标签名前缀, 如前缀为 clip_ 时, 标签为 clip_mobile.
+// 翻译时需使用相同前缀的 WithTranslationTagPrefix.
+func WithTagPrefix(prefix string) ValidationOption {
+	return func(c *validationConfig) {
+		c.prefix = prefix
+	}
+}
+
+// WithTimeValidator 时间相关标签使用的 TimeValidator, 用于指定时区和当前时间, 默认使用 UTC 和 time.Now.
+func WithTimeValidator(tv *TimeValidator) ValidationOption {
+	return func(c *validationConfig) {
+		c.time = tv
+	}
+}
+
+// WithStructValidation 注册结构体级别的校验, 见 validator.Validate.RegisterStructValidation.
+func WithStructValidation(fn validator.StructLevelFunc, types ...any) ValidationOption {
+	return func(c *validationConfig) {
+		c.structs = append(c.structs, structValidation{fn, types})
+	}
+}
+
+// WithValidationTagName 校验使用的结构体标签名, 注册时调用 validator.Validate.SetTagName, 默认 validate.
+func WithValidationTagName(name string) ValidationOption {
+	return func(c *validationConfig) {
+		c.tagName = name
+	}
+}
+
+// WithRequestStructs 注册时解析并缓存请求结构体中校验标签的参数, 参数错误时 RegisterValidation 返回错误.
+// 未经检查的结构体在校验时遇到参数错误的标签会 panic, 建议传入所有的请求结构体.
+func WithRequestStructs(structs ...any) ValidationOption {
+	return func(c *validationConfig) {
+		c.requests = append(c.requests, structs...)
+	}
+}
+
+func newValidationConfig(opts ...ValidationOption) *validationConfig {
+	c := &validationConfig{excludes: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// selected 根据选项选择标签, 标签名包含前缀.
+func (c *validationConfig) selected() ([]Validation, error) {
+	var err error
+	var vs []Validation
+	if len(c.tags) == 0 {
+		vs = append(vs, validations...)
+	} else {
+		for _, tag := range c.tags {
+			v, ok := lookupValidation(tag)
+			if !ok {
+				err = multierr.Append(err, fmt.Errorf("unknown tag %q", tag))
+				continue
 			}
-			return nil
-		},
-		decimal.Decimal{},
-	)
-
-	err := multierr.Combine(
-		valid.RegisterValidation("mobile", ValidIsMobile),
-		valid.RegisterValidation("decimal", ValidIsDecimal),
-		valid.RegisterValidation("decimal_gt", ValidIsDecimalGt),
-		valid.RegisterValidation("decimal_gte", ValidIsDecimalGte),
-		valid.RegisterValidation("decimal_lt", ValidIsDecimalLt),
-		valid.RegisterValidation("decimal_lte", ValidIsDecimalLte),
-		valid.RegisterValidation("decimal_min", ValidDecimalMinOf),
-		valid.RegisterValidation("decimal_max", ValidDecimalMaxOf),
-		valid.RegisterValidation("number_gt0", ValidNumberGt0),
-		valid.RegisterValidation("number_gte0", ValidNumberGte0),
-	)
+			vs = append(vs, v)
+		}
+	}
+
+	result := vs[:0]
+	for _, v := range vs {
+		if _, ok := c.excludes[v.Tag]; ok {
+			continue
+		}
+		if fn, ok := timeValidations[v.Tag]; ok && c.time != nil {
+			v.Func = fn(c.time)
+		}
+		v.Tag = c.prefix + v.Tag
+		result = append(result, v)
+	}
+	return result, err
+}
+
+// paramChecks 已选择的带参数标签及其参数的解析函数, 标签名包含前缀.
+func (c *validationConfig) paramChecks(vs []Validation) map[string]func(param string) error {
+	checks := make(map[string]func(param string) error)
+	for _, v := range vs {
+		if check, ok := paramTags[strings.TrimPrefix(v.Tag, c.prefix)]; ok {
+			checks[v.Tag] = check
+		}
+	}
+	return checks
+}
+
+func lookupValidation(tag string) (Validation, bool) {
+	for _, v := range validations {
+		if v.Tag == tag {
+			return v, true
+		}
+	}
+	return Validation{}, false
+}
+
+// RegisterValidation 注册自定义校验标签以及 decimal.Decimal, decimal.NullDecimal 的类型转换.
+// 默认注册所有标签, 可使用选项选择标签, 添加前缀以及注册结构体级别的校验.
+// 使用 WithRequestStructs 时在注册时检查请求结构体的标签参数.
+func RegisterValidation(valid *validator.Validate, opts ...ValidationOption) error {
+	c := newValidationConfig(opts...)
+	vs, err := c.selected()
+	if err != nil {
+		return fmt.Errorf("validator: register validation failed, %w", err)
+	}
+
+	if c.tagName != "" {
+		valid.SetTagName(c.tagName)
+	}
+	valid.RegisterCustomTypeFunc(decimalCustomTypeFunc, decimal.Decimal{}, decimal.NullDecimal{})
+	for _, v := range vs {
+		if e := valid.RegisterValidation(v.Tag, v.Func); e != nil {
+			err = multierr.Append(err, fmt.Errorf("tag %q: %w", v.Tag, e))
+		}
+	}
+	for _, s := range c.structs {
+		valid.RegisterStructValidation(s.fn, s.types...)
+	}
+	if len(c.requests) > 0 {
+		err = multierr.Append(err, checkTagParams(c.tagName, c.requests, c.paramChecks(vs)))
+	}
 	if err != nil {
 		return fmt.Errorf("validator: register validation failed, %w", err)
 	}
 	return nil
 }
 
-// ValidIsMobile 校验是否为手机
+// Validations 获取使用相同选项时 RegisterValidation 注册的标签及说明, 按注册顺序排列, 未知的标签被忽略.
+func Validations(opts ...ValidationOption) []Validation {
+	vs, _ := newValidationConfig(opts...).selected()
+	return vs
+}
+
+// ValidateTags 预先解析并缓存结构体中校验标签的参数, 参数错误时返回错误.
+// 选项与 RegisterValidation 相同, 只检查使用这些选项时注册的标签, 标签名前缀由 WithTagPrefix 指定,
+// 结构体标签名由 WithValidationTagName 指定. 注册时可以直接使用 WithRequestStructs.
+func ValidateTags(structs []any, opts ...ValidationOption) error {
+	c := newValidationConfig(opts...)
+	vs, err := c.selected()
+	if err != nil {
+		return fmt.Errorf("validator: validate tags failed, %w", err)
+	}
+	return checkTagParams(c.tagName, structs, c.paramChecks(vs))
+}
+
+// checkTagParams 使用 checks 检查结构体中带参数的标签, tagName 为空时使用 "validate".
+func checkTagParams(tagName string, structs []any, checks map[string]func(param string) error) error {
+	if tagName == "" {
+		tagName = "validate"
+	}
+	var err error
+	visited := make(map[reflect.Type]struct{})
+	for _, s := range structs {
+		err = multierr.Append(err, validateTags(tagName, reflect.TypeOf(s), checks, visited))
+	}
+	return err
+}
+
+func validateTags(tagName string, typ reflect.Type, checks map[string]func(param string) error, visited map[reflect.Type]struct{}) error {
+	for typ != nil && (typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice ||
+		typ.Kind() == reflect.Array || typ.Kind() == reflect.Map) {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct || typ == decimalType || typ == nullDecimalType {
+		return nil
+	}
+	if _, ok := visited[typ]; ok {
+		return nil
+	}
+	visited[typ] = struct{}{}
+
+	var err error
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		for _, tag := range splitTags(field.Tag.Get(tagName)) {
+			name, param, _ := strings.Cut(tag, "=")
+			check, ok := checks[name]
+			if !ok {
+				continue
+			}
+			if e := check(param); e != nil {
+				err = multierr.Append(err, fmt.Errorf("binding: %s.%s tag %q has invalid param %q: %v", typ.Name(), field.Name, name, param, e))
+			}
+		}
+		err = multierr.Append(err, validateTags(tagName, field.Type, checks, visited))
+	}
+	return err
+}
+
+// splitTags 拆分校验标签, 包括 '|' 分隔的或条件
+func splitTags(tag string) []string {
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	return strings.FieldsFunc(tag, func(r rune) bool { return r == ',' || r == '|' })
+}
+
+// ValidIsMobile 校验是否为手机号, 带参数时校验指定地区的手机号, 如 mobile=CN, mobile=HK.
 func ValidIsMobile(fl validator.FieldLevel) bool {
+	if region := fl.Param(); region != "" {
+		return IsMobileOf(fl.Field().String(), region)
+	}
 	return IsMobile(fl.Field().String())
 }
 
-// ValidIsDecimal 校验是否为字符串 decimal
-func ValidIsDecimal(fl validator.FieldLevel) bool {
-	field := fl.Field()
-	if field.Kind() == reflect.String {
-		return IsDecimal(field.String())
+// ValidIsPhone 校验是否是电话号码, 包括固话.
+// 带参数时, 如 phone=CN, 支持该地区的国内格式和国际格式, 否则必须是国际格式.
+func ValidIsPhone(fl validator.FieldLevel) bool {
+	if region := fl.Param(); region != "" {
+		return IsPhoneOf(fl.Field().String(), region)
 	}
+	_, err := ParsePhone(fl.Field().String(), "")
+	return err == nil
+}
 
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+// ValidIsE164 校验是否是 E.164 格式的电话号码, 见 IsE164.
+func ValidIsE164(fl validator.FieldLevel) bool {
+	return IsE164(fl.Field().String())
 }
 
-// ValidIsDecimalGt 校验是否为字符串数字且大于0
-func ValidIsDecimalGt(fl validator.FieldLevel) bool {
-	field := fl.Field()
-	param := fl.Param()
+// ValidIsIDCard 校验是否为 18 位居民身份证号码
+func ValidIsIDCard(fl validator.FieldLevel) bool {
+	return IsIDCard(fl.Field().String())
+}
 
-	if field.Kind() == reflect.String {
-		return IsDecimalGt(field.String(), param)
-	}
+// ValidIsHKMacauPermit 校验是否为港澳居民来往内地通行证号码
+func ValidIsHKMacauPermit(fl validator.FieldLevel) bool {
+	return IsHKMacauPermit(fl.Field().String())
+}
 
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+// ValidIsTaiwanPermit 校验是否为台湾居民来往大陆通行证号码
+func ValidIsTaiwanPermit(fl validator.FieldLevel) bool {
+	return IsTaiwanPermit(fl.Field().String())
 }
 
-// ValidIsDecimalGte 校验是否为字符串数字且大于等于0
-func ValidIsDecimalGte(fl validator.FieldLevel) bool {
-	field := fl.Field()
-	param := fl.Param()
+// ValidIsUSCC 校验是否为统一社会信用代码
+func ValidIsUSCC(fl validator.FieldLevel) bool {
+	return IsUSCC(fl.Field().String())
+}
 
-	if field.Kind() == reflect.String {
-		return IsDecimalGte(field.String(), param)
+// ValidIsBankCard 校验是否为银联, Visa 或万事达卡号.
+// 参数为以空格分隔的卡组织, 如 bank_card=unionpay visa, 为空时不限制卡组织.
+func ValidIsBankCard(fl validator.FieldLevel) bool {
+	var schemes []CardScheme
+	for _, s := range strings.Fields(fl.Param()) {
+		schemes = append(schemes, CardScheme(s))
 	}
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	return IsBankCard(fl.Field().String(), schemes...)
 }
 
-func ValidIsDecimalLt(fl validator.FieldLevel) bool {
-	field := fl.Field()
-	param := fl.Param()
+// ValidIsBankCardInBins 校验卡号前缀是否在当前加载的 BIN 表中
+func ValidIsBankCardInBins(fl validator.FieldLevel) bool {
+	return IsBankCardInBins(fl.Field().String())
+}
+
+// ValidIsIBAN 校验是否为 IBAN
+func ValidIsIBAN(fl validator.FieldLevel) bool {
+	return IsIBAN(fl.Field().String())
+}
+
+// ValidIsSwiftBic 校验是否为 SWIFT/BIC 代码
+func ValidIsSwiftBic(fl validator.FieldLevel) bool {
+	return IsSwiftBic(fl.Field().String())
+}
 
-	if field.Kind() == reflect.String {
-		return IsDecimalLt(field.String(), param)
+// ValidIsCNPostalCode 校验是否为中国邮政编码
+func ValidIsCNPostalCode(fl validator.FieldLevel) bool {
+	return IsCNPostalCode(fl.Field().String())
+}
+
+// ValidIsLicensePlate 校验是否为机动车号牌, 包括新能源号牌
+func ValidIsLicensePlate(fl validator.FieldLevel) bool {
+	return IsLicensePlate(fl.Field().String())
+}
+
+// ValidIsNewEnergyPlate 校验是否为新能源号牌
+func ValidIsNewEnergyPlate(fl validator.FieldLevel) bool {
+	return IsNewEnergyPlate(fl.Field().String())
+}
+
+// ValidIsRegionCode 校验是否为行政区划代码.
+// 参数为以空格分隔的级别, 如 region_code=city district, 为空时不限制级别.
+func ValidIsRegionCode(fl validator.FieldLevel) bool {
+	var levels []RegionLevel
+	for _, s := range strings.Fields(fl.Param()) {
+		levels = append(levels, RegionLevel(s))
 	}
+	return IsRegionCode(fl.Field().String(), levels...)
+}
 
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+// ValidIsRegionIn 校验行政区划代码是否隶属于同一结构体中另一字段的行政区划, 如 region_in=Province.
+func ValidIsRegionIn(fl validator.FieldLevel) bool {
+	other, kind, _, found := fl.GetStructFieldOK2()
+	if !found || kind != reflect.String {
+		return false
+	}
+	return IsRegionIn(fl.Field().String(), other.String())
 }
 
-func ValidIsDecimalLte(fl validator.FieldLevel) bool {
+// ValidIsDecimal 校验是否为 decimal.
+// 支持 string, decimal.Decimal, decimal.NullDecimal, 整型, 浮点型以及它们的指针.
+func ValidIsDecimal(fl validator.FieldLevel) bool {
 	field := fl.Field()
-	param := fl.Param()
-
-	if field.Kind() == reflect.String {
-		return IsDecimalLte(field.String(), param)
+	_, ok, supported := decimalFromField(field)
+	if !supported {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
 	}
+	return ok
+}
+
+// ValidIsDecimalGt 校验是否为 decimal 且大于参数
+func ValidIsDecimalGt(fl validator.FieldLevel) bool {
+	return validDecimalCompare(fl, decimal.Decimal.GreaterThan)
+}
 
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+// ValidIsDecimalGte 校验是否为 decimal 且大于等于参数
+func ValidIsDecimalGte(fl validator.FieldLevel) bool {
+	return validDecimalCompare(fl, decimal.Decimal.GreaterThanOrEqual)
+}
+
+// ValidIsDecimalLt 校验是否为 decimal 且小于参数
+func ValidIsDecimalLt(fl validator.FieldLevel) bool {
+	return validDecimalCompare(fl, decimal.Decimal.LessThan)
+}
+
+// ValidIsDecimalLte 校验是否为 decimal 且小于等于参数
+func ValidIsDecimalLte(fl validator.FieldLevel) bool {
+	return validDecimalCompare(fl, decimal.Decimal.LessThanOrEqual)
 }
 
 func ValidDecimalMinOf(fl validator.FieldLevel) bool {
@@ -106,20 +486,160 @@ func ValidDecimalMaxOf(fl validator.FieldLevel) bool {
 	return ValidIsDecimalLte(fl)
 }
 
-func ValidNumberGt0(fl validator.FieldLevel) bool {
-	field := fl.Field()
+// ValidDecimalScale 校验是否为 decimal 且有效小数位数不超过参数, 如 decimal_scale=2, 小数末尾的 0 不计入.
+func ValidDecimalScale(fl validator.FieldLevel) bool {
+	n, err := parseDigitsParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	d, ok := fieldDecimal(fl)
+	return ok && decimalScale(d) <= n
+}
 
-	if field.Kind() == reflect.String {
-		return IsNumberGt0(field.String())
+// ValidDecimalPrecision 校验是否为 decimal 且有效数字总位数不超过参数, 如 decimal_precision=18.
+// 总位数为整数位数加有效小数位数, 同数据库 DECIMAL(p,s) 中的 p.
+func ValidDecimalPrecision(fl validator.FieldLevel) bool {
+	n, err := parseDigitsParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
 	}
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	d, ok := fieldDecimal(fl)
+	return ok && decimalPrecision(d) <= n
 }
 
-func ValidNumberGte0(fl validator.FieldLevel) bool {
+// ValidDecimalBetween 校验是否为 decimal 且在闭区间内, 如 decimal_between=0.01~100.
+func ValidDecimalBetween(fl validator.FieldLevel) bool {
+	min, max, err := parseDecimalRangeParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	d, ok := fieldDecimal(fl)
+	return ok && d.GreaterThanOrEqual(min) && d.LessThanOrEqual(max)
+}
+
+// ValidDecimalMultipleOf 校验是否为 decimal 且为参数的整数倍, 常用于价格最小变动单位, 如 decimal_multiple_of=0.05.
+func ValidDecimalMultipleOf(fl validator.FieldLevel) bool {
+	step, err := parseDecimalStepParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	d, ok := fieldDecimal(fl)
+	return ok && d.Mod(step).IsZero()
+}
+
+// ValidDecimalGtField 校验是否为 decimal 且大于同一结构体中的另一字段, 如 decimal_gtfield=MinAmount.
+func ValidDecimalGtField(fl validator.FieldLevel) bool {
+	return validDecimalField(fl, false, decimal.Decimal.GreaterThan)
+}
+
+// ValidDecimalGteField 校验是否为 decimal 且大于等于同一结构体中的另一字段
+func ValidDecimalGteField(fl validator.FieldLevel) bool {
+	return validDecimalField(fl, false, decimal.Decimal.GreaterThanOrEqual)
+}
+
+// ValidDecimalLtField 校验是否为 decimal 且小于同一结构体中的另一字段
+func ValidDecimalLtField(fl validator.FieldLevel) bool {
+	return validDecimalField(fl, false, decimal.Decimal.LessThan)
+}
+
+// ValidDecimalLteField 校验是否为 decimal 且小于等于同一结构体中的另一字段, 如 decimal_ltefield=Price.
+func ValidDecimalLteField(fl validator.FieldLevel) bool {
+	return validDecimalField(fl, false, decimal.Decimal.LessThanOrEqual)
+}
+
+// ValidDecimalGtCsField 校验是否为 decimal 且大于顶层结构体中的字段, 如 decimal_gtcsfield=Limit.MinAmount.
+func ValidDecimalGtCsField(fl validator.FieldLevel) bool {
+	return validDecimalField(fl, true, decimal.Decimal.GreaterThan)
+}
+
+// ValidDecimalGteCsField 校验是否为 decimal 且大于等于顶层结构体中的字段
+func ValidDecimalGteCsField(fl validator.FieldLevel) bool {
+	return validDecimalField(fl, true, decimal.Decimal.GreaterThanOrEqual)
+}
+
+// ValidDecimalLtCsField 校验是否为 decimal 且小于顶层结构体中的字段
+func ValidDecimalLtCsField(fl validator.FieldLevel) bool {
+	return validDecimalField(fl, true, decimal.Decimal.LessThan)
+}
+
+// ValidDecimalLteCsField 校验是否为 decimal 且小于等于顶层结构体中的字段
+func ValidDecimalLteCsField(fl validator.FieldLevel) bool {
+	return validDecimalField(fl, true, decimal.Decimal.LessThanOrEqual)
+}
+
+// validDecimalField 字段与另一字段比较, 另一字段不存在或不是有效的 decimal 时校验失败.
+// cross 为 true 时从顶层结构体查找字段, 否则从字段所在的结构体查找.
+func validDecimalField(fl validator.FieldLevel, cross bool, cmp func(d, t decimal.Decimal) bool) bool {
+	d, ok := fieldDecimal(fl)
+	if !ok {
+		return false
+	}
+	var other reflect.Value
+	var found bool
+	if cross {
+		other, _, _, found = fl.GetStructFieldOKAdvanced2(fl.Top(), fl.Param())
+	} else {
+		other, _, _, found = fl.GetStructFieldOK2()
+	}
+	if !found {
+		return false
+	}
+	t, ok, supported := decimalFromField(other)
+	if !supported || !ok {
+		return false
+	}
+	return cmp(d, t)
+}
+
+// validDecimalCompare 字段与参数比较, 参数无效时 panic.
+func validDecimalCompare(fl validator.FieldLevel, cmp func(d, t decimal.Decimal) bool) bool {
+	t, err := parseDecimalParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	d, ok := fieldDecimal(fl)
+	return ok && cmp(d, t)
+}
+
+// panicBadParam 标签参数错误属于编程错误, 与 validator 内置标签一样直接 panic, 而不是校验失败.
+// 注册时使用 WithRequestStructs 可以提前返回错误.
+func panicBadParam(fl validator.FieldLevel, err error) {
+	panic(fmt.Sprintf("binding: %s tag %q has invalid param %q: %v", fl.StructFieldName(), fl.GetTag(), fl.Param(), err))
+}
+
+// fieldDecimal 获取字段的 decimal 值, 不支持的字段类型 panic.
+func fieldDecimal(fl validator.FieldLevel) (decimal.Decimal, bool) {
 	field := fl.Field()
+	d, ok, supported := decimalFromField(field)
+	if !supported {
+		panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	}
+	return d, ok
+}
+
+// ValidNumberGt0 校验是否为大于 0 的整数, 支持整型, 无符号整型, 字符串(包括 json.Number)以及它们的指针.
+func ValidNumberGt0(fl validator.FieldLevel) bool {
+	n, ok := fieldNumber(fl, IsNumberGt0)
+	return ok && n.Sign() > 0
+}
 
-	if field.Kind() == reflect.String {
-		return IsNumberGte0(field.String())
+// ValidNumberGte0 校验是否为大于等于 0 的整数, 支持的类型同 ValidNumberGt0.
+func ValidNumberGte0(fl validator.FieldLevel) bool {
+	n, ok := fieldNumber(fl, IsNumberGte0)
+	return ok && n.Sign() >= 0
+}
+
+// ValidNumberRange 校验是否为闭区间内的整数, 如 number_range=1~9223372036854775808.
+// 字符串不受 int64 范围限制, 适用于以字符串传递的 snowflake id 等, 支持的类型同 ValidNumberGt0.
+// 参数须为 min <= max 的整数, 注册时使用 WithRequestStructs 检查, 否则在校验时 panic.
+func ValidNumberRange(fl validator.FieldLevel) bool {
+	min, max, err := parseNumberRangeParam(fl.Param())
+	if err != nil {
+		panicBadParam(fl, err)
+	}
+	n, ok := fieldNumber(fl, rxInteger.MatchString)
+	if !ok {
+		return false
 	}
-	panic(fmt.Sprintf("Bad field type %T", field.Interface()))
+	return n.Cmp(min) >= 0 && n.Cmp(max) <= 0
 }
@@ -0,0 +1,156 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestValidate(t *testing.T) *validator.Validate {
+	valid := validator.New()
+	require.NoError(t, RegisterValidation(valid))
+	return valid
+}
+
+// fieldErrorTags 字段错误列表, 格式为 "字段:标签".
+func fieldErrorTags(t *testing.T, err error) []string {
+	t.Helper()
+	require.Error(t, err)
+	errs, ok := err.(validator.ValidationErrors)
+	require.True(t, ok, err.Error())
+	tags := make([]string, 0, len(errs))
+	for _, fe := range errs {
+		tags = append(tags, fe.Field()+":"+fe.Tag())
+	}
+	return tags
+}
+
+func TestRegisterValidationOptions(t *testing.T) {
+	type Order struct {
+		Mobile string `validate:"clip_mobile"`
+		Amount string `validate:"clip_decimal_gt=0"`
+		Min    string
+		Max    string
+	}
+	valid := validator.New()
+	require.NoError(t, valid.RegisterValidation("mobile", func(validator.FieldLevel) bool { return true }))
+	require.NoError(t, RegisterValidation(valid,
+		WithTagPrefix("clip_"),
+		WithValidationTags("mobile", "decimal_gt", "decimal_lt"),
+		WithoutValidationTags("decimal_lt"),
+		WithStructValidation(func(sl validator.StructLevel) {
+			o := sl.Current().Interface().(Order)
+			if !IsDecimalLte(o.Min, o.Max) {
+				sl.ReportError(o.Min, "Min", "Min", "decimal_ltefield", "Max")
+			}
+		}, Order{}),
+	))
+
+	// 已注册的 mobile 标签不被覆盖
+	type App struct {
+		Mobile string `validate:"mobile"`
+	}
+	require.NoError(t, valid.Struct(&App{Mobile: "123"}))
+	require.Panics(t, func() {
+		_ = valid.Struct(&struct {
+			Amount string `validate:"clip_decimal_lt=1"`
+		}{})
+	})
+
+	require.NoError(t, valid.Struct(&Order{Mobile: "13800138000", Amount: "1", Min: "1", Max: "2"}))
+	err := valid.Struct(&Order{Mobile: "123", Amount: "0", Min: "3", Max: "2"})
+	require.Equal(t, []string{"Mobile:clip_mobile", "Amount:clip_decimal_gt", "Min:decimal_ltefield"}, fieldErrorTags(t, err))
+
+	require.Error(t, RegisterValidation(validator.New(), WithValidationTags("unknown")))
+}
+
+func TestRegisterValidationRequestStructs(t *testing.T) {
+	type Item struct {
+		Price string `binding:"clip_decimal_between=1~0"`
+	}
+	type Order struct {
+		Amount string `binding:"required,clip_decimal_gt=0"`
+		Scale  string `binding:"clip_decimal_scale=-1"`
+		Items  []Item
+		Other  string `validate:"clip_decimal_gt=x"`
+	}
+	err := RegisterValidation(validator.New(),
+		WithTagPrefix("clip_"),
+		WithValidationTagName("binding"),
+		WithRequestStructs(&Order{}),
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Order.Scale")
+	require.Contains(t, err.Error(), "Item.Price")
+	require.NotContains(t, err.Error(), "Order.Other")
+
+	// 未注册的标签不检查
+	require.NoError(t, RegisterValidation(validator.New(),
+		WithTagPrefix("clip_"),
+		WithValidationTagName("binding"),
+		WithoutValidationTags("decimal_scale", "decimal_between"),
+		WithRequestStructs(&Order{}),
+	))
+
+	valid := validator.New()
+	require.NoError(t, RegisterValidation(valid, WithValidationTagName("binding"), WithRequestStructs(struct {
+		Amount string `binding:"decimal_gt=0"`
+	}{})))
+	require.Error(t, valid.Struct(&struct {
+		Amount string `binding:"decimal_gt=0"`
+	}{"0"}))
+}
+
+func TestValidateTags(t *testing.T) {
+	type Item struct {
+		Price string `validate:"decimal_gt=0"`
+		Bad   string `validate:"required,decimal_lte=1e"`
+	}
+	type Order struct {
+		Amount   decimal.Decimal `validate:"decimal_gte=0.01|decimal_lt=-x"`
+		Items    []Item
+		Main     *Item
+		Discount string `binding:"clip_decimal_gt=abc"`
+		At       string `binding:"clip_time_within=1d"`
+	}
+	require.NoError(t, ValidateTags([]any{struct {
+		Price string `validate:"decimal_gt=0,decimal_max=100"`
+	}{}}))
+
+	err := ValidateTags([]any{&Order{}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Order.Amount tag "decimal_lt" has invalid param "-x"`)
+	require.Contains(t, err.Error(), `Item.Bad tag "decimal_lte" has invalid param "1e"`)
+	require.NotContains(t, err.Error(), "Discount")
+
+	// 带前缀的标签
+	err = ValidateTags([]any{Order{}}, WithValidationTagName("binding"), WithTagPrefix("clip_"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Order.Discount tag "clip_decimal_gt" has invalid param "abc"`)
+	require.Contains(t, err.Error(), `Order.At tag "clip_time_within" has invalid param "1d"`)
+	require.NotContains(t, err.Error(), "Amount")
+
+	err = ValidateTags([]any{Order{}}, WithValidationTagName("binding"), WithTagPrefix("clip_"), WithValidationTags("decimal_gt"))
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "clip_time_within")
+	require.Error(t, ValidateTags([]any{Order{}}, WithValidationTags("unknown")))
+}
+
+func TestValidations(t *testing.T) {
+	vs := Validations()
+	require.Len(t, vs, len(validations))
+	tags := make(map[string]struct{}, len(vs))
+	for _, v := range vs {
+		require.NotEmpty(t, v.Description, v.Tag)
+		require.NotNil(t, v.Func, v.Tag)
+		require.NotContains(t, tags, v.Tag)
+		tags[v.Tag] = struct{}{}
+	}
+
+	vs = Validations(WithTagPrefix("clip_"), WithValidationTags("mobile", "unknown"))
+	require.Len(t, vs, 1)
+	require.Equal(t, "clip_mobile", vs[0].Tag)
+	require.Equal(t, validations[0].Description, vs[0].Description)
+}
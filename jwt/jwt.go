@@ -0,0 +1,311 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// error defined
+var (
+	ErrTokenMalformed        = errors.New("jwt: token is malformed")
+	ErrTokenUnverifiable     = errors.New("jwt: token is unverifiable")
+	ErrAlgorithmNone         = errors.New("jwt: 'none' algorithm is not allowed")
+	ErrAlgorithmMismatch     = errors.New("jwt: algorithm mismatch with the key")
+	ErrKeyNotFound           = errors.New("jwt: key not found")
+	ErrInvalidKeyType        = errors.New("jwt: key is of invalid type")
+	ErrSignatureInvalid      = errors.New("jwt: signature is invalid")
+	ErrTokenExpired          = errors.New("jwt: token is expired")
+	ErrTokenNotValidYet      = errors.New("jwt: token is not valid yet")
+	ErrTokenUsedBeforeIssued = errors.New("jwt: token used before issued")
+	ErrTokenRequiredClaim    = errors.New("jwt: token is missing required claim")
+	ErrTokenInvalidAudience  = errors.New("jwt: token has invalid audience")
+	ErrTokenInvalidIssuer    = errors.New("jwt: token has invalid issuer")
+)
+
+// Header jws 头部
+type Header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// Key 带 ID 的密钥, 签名算法与密钥绑定.
+// 签名时 Key 为 []byte, *rsa.PrivateKey 或 *ecdsa.PrivateKey,
+// 验签时 Key 为 []byte, *rsa.PublicKey 或 *ecdsa.PublicKey.
+type Key struct {
+	ID     string
+	Method SigningMethod
+	Key    any
+}
+
+// Claims 声明, 自定义声明内嵌 RegisteredClaims 即可.
+type Claims interface {
+	GetRegisteredClaims() *RegisteredClaims
+}
+
+// Audience 受众, json 中可以是字符串或字符串数组.
+type Audience []string
+
+// UnmarshalJSON implement json.Unmarshaler
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+	var ss []string
+	if err := json.Unmarshal(b, &ss); err != nil {
+		return err
+	}
+	*a = ss
+	return nil
+}
+
+// MarshalJSON implement json.Marshaler, 单个受众时序列化为字符串.
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// Contains 是否包含指定受众
+func (a Audience) Contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// NumericDate RFC 7519 2 NumericDate, unix 秒.
+// json 中可以带有小数, 解码时舍去小数部分, 编码为整数.
+type NumericDate int64
+
+// NewNumericDate 由 time.Time 构造 NumericDate
+func NewNumericDate(t time.Time) NumericDate { return NumericDate(t.Unix()) }
+
+// Time 转换为 time.Time
+func (d NumericDate) Time() time.Time { return time.Unix(int64(d), 0) }
+
+// UnmarshalJSON implement json.Unmarshaler
+func (d *NumericDate) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil || strings.HasPrefix(string(b), `"`) {
+		return fmt.Errorf("jwt: invalid numeric date %s", b)
+	}
+	if v, err := n.Int64(); err == nil {
+		*d = NumericDate(v)
+		return nil
+	}
+	f, err := n.Float64()
+	if err != nil || math.IsInf(f, 0) || f >= math.MaxInt64 || f <= math.MinInt64 {
+		return fmt.Errorf("jwt: invalid numeric date %s", b)
+	}
+	*d = NumericDate(math.Trunc(f))
+	return nil
+}
+
+// RegisteredClaims RFC 7519 4.1 注册的声明, 时间为 unix 秒.
+type RegisteredClaims struct {
+	Issuer    string      `json:"iss,omitempty"`
+	Subject   string      `json:"sub,omitempty"`
+	Audience  Audience    `json:"aud,omitempty"`
+	ExpiresAt NumericDate `json:"exp,omitempty"`
+	NotBefore NumericDate `json:"nbf,omitempty"`
+	IssuedAt  NumericDate `json:"iat,omitempty"`
+	ID        string      `json:"jti,omitempty"`
+}
+
+// GetRegisteredClaims implement Claims
+func (c *RegisteredClaims) GetRegisteredClaims() *RegisteredClaims { return c }
+
+// Sign 使用 key 签发 token, header 中带有 key 的 ID.
+func Sign(key *Key, claims any) (string, error) {
+	if key == nil || key.Method == nil {
+		return "", ErrInvalidKeyType
+	}
+	header, err := json.Marshal(&Header{
+		Alg: key.Method.Alg(),
+		Typ: "JWT",
+		Kid: key.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingString := encodeSegment(header) + "." + encodeSegment(payload)
+	sig, err := key.Method.Sign([]byte(signingString), key.Key)
+	if err != nil {
+		return "", err
+	}
+	return signingString + "." + encodeSegment(sig), nil
+}
+
+// Option parser option
+type Option func(*Parser)
+
+// WithKeys 验签的密钥, 根据 header 中的 kid 选择密钥, 无 kid 时使用 ID 为空的密钥.
+func WithKeys(keys ...*Key) Option {
+	return func(p *Parser) {
+		for _, k := range keys {
+			p.keys[k.ID] = k
+		}
+	}
+}
+
+// WithLeeway 时间校验的容差
+func WithLeeway(leeway time.Duration) Option {
+	return func(p *Parser) {
+		p.leeway = leeway
+	}
+}
+
+// WithIssuer 校验签发者
+func WithIssuer(iss string) Option {
+	return func(p *Parser) {
+		p.issuer = iss
+	}
+}
+
+// WithAudience 校验受众
+func WithAudience(aud string) Option {
+	return func(p *Parser) {
+		p.audience = aud
+	}
+}
+
+// WithExpirationRequired 要求必须带有 exp
+func WithExpirationRequired() Option {
+	return func(p *Parser) {
+		p.requireExp = true
+	}
+}
+
+// WithTimeFunc 当前时间函数, 默认 time.Now
+func WithTimeFunc(f func() time.Time) Option {
+	return func(p *Parser) {
+		p.timeFunc = f
+	}
+}
+
+// Parser 解析并校验 token
+type Parser struct {
+	keys       map[string]*Key
+	leeway     time.Duration
+	issuer     string
+	audience   string
+	requireExp bool
+	timeFunc   func() time.Time
+}
+
+// NewParser 新建解析器
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{
+		keys:     make(map[string]*Key),
+		timeFunc: time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Parse 解析 token 到 claims, 并校验签名和注册的声明.
+func (p *Parser) Parse(token string, claims Claims) (*Header, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrTokenMalformed
+	}
+
+	header := &Header{}
+	if err := decodeSegmentJSON(parts[0], header); err != nil {
+		return nil, err
+	}
+	if header.Alg == "" || strings.EqualFold(header.Alg, "none") {
+		return nil, ErrAlgorithmNone
+	}
+	method, ok := GetSigningMethod(header.Alg)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrTokenUnverifiable, header.Alg)
+	}
+	key, ok := p.keys[header.Kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: kid %q", ErrKeyNotFound, header.Kid)
+	}
+	// 算法必须与密钥绑定的算法一致, 防止算法混淆
+	if key.Method == nil || key.Method.Alg() != method.Alg() {
+		return nil, ErrAlgorithmMismatch
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrTokenMalformed
+	}
+	if err = method.Verify([]byte(parts[0]+"."+parts[1]), sig, key.Key); err != nil {
+		return nil, err
+	}
+
+	if err = decodeSegmentJSON(parts[1], claims); err != nil {
+		return nil, err
+	}
+	if err = p.validate(claims.GetRegisteredClaims()); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func (p *Parser) validate(c *RegisteredClaims) error {
+	if c == nil {
+		return ErrTokenMalformed
+	}
+	now := NewNumericDate(p.timeFunc())
+	leeway := NumericDate(p.leeway / time.Second)
+
+	if c.ExpiresAt == 0 {
+		if p.requireExp {
+			return fmt.Errorf("%w: exp", ErrTokenRequiredClaim)
+		}
+	} else if now > c.ExpiresAt+leeway {
+		return ErrTokenExpired
+	}
+	if c.NotBefore != 0 && now+leeway < c.NotBefore {
+		return ErrTokenNotValidYet
+	}
+	if c.IssuedAt != 0 && now+leeway < c.IssuedAt {
+		return ErrTokenUsedBeforeIssued
+	}
+	if p.issuer != "" && c.Issuer != p.issuer {
+		return ErrTokenInvalidIssuer
+	}
+	if p.audience != "" && !c.Audience.Contains(p.audience) {
+		return ErrTokenInvalidAudience
+	}
+	return nil
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeSegmentJSON(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return ErrTokenMalformed
+	}
+	if err = json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrTokenMalformed, err)
+	}
+	return nil
+}
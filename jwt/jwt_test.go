@@ -0,0 +1,220 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testClaims struct {
+	RegisteredClaims
+	Name string `json:"name"`
+}
+
+func TestSignParse(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		signKey *Key
+		keys    []*Key
+	}{
+		{
+			"HS256",
+			&Key{"k1", SigningMethodHS256, []byte("secret")},
+			[]*Key{{"k1", SigningMethodHS256, []byte("secret")}},
+		},
+		{
+			"HS512 without kid",
+			&Key{"", SigningMethodHS512, []byte("secret")},
+			[]*Key{{"", SigningMethodHS512, []byte("secret")}},
+		},
+		{
+			"RS256",
+			&Key{"rsa", SigningMethodRS256, rsaKey},
+			[]*Key{{"rsa", SigningMethodRS256, &rsaKey.PublicKey}},
+		},
+		{
+			"PS384",
+			&Key{"rsa", SigningMethodPS384, rsaKey},
+			[]*Key{{"rsa", SigningMethodPS384, &rsaKey.PublicKey}},
+		},
+		{
+			"ES256",
+			&Key{"ec", SigningMethodES256, ecKey},
+			[]*Key{{"ec", SigningMethodES256, &ecKey.PublicKey}},
+		},
+		{
+			"ES384",
+			&Key{"ec", SigningMethodES384, ec384Key},
+			[]*Key{{"ec", SigningMethodES384, &ec384Key.PublicKey}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now()
+			token, err := Sign(tt.signKey, &testClaims{
+				RegisteredClaims: RegisteredClaims{
+					Issuer:    "clip",
+					Audience:  Audience{"api"},
+					ExpiresAt: NewNumericDate(now.Add(time.Hour)),
+					IssuedAt:  NewNumericDate(now),
+				},
+				Name: "jjl",
+			})
+			require.NoError(t, err)
+
+			claims := &testClaims{}
+			header, err := NewParser(WithKeys(tt.keys...), WithIssuer("clip"), WithAudience("api")).Parse(token, claims)
+			require.NoError(t, err)
+			require.Equal(t, tt.signKey.ID, header.Kid)
+			require.Equal(t, "jjl", claims.Name)
+
+			_, err = NewParser(WithKeys(tt.keys...)).Parse(token[:len(token)-4]+"AAAA", &testClaims{})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseAttack(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keys := []*Key{
+		{"rsa", SigningMethodRS256, &rsaKey.PublicKey},
+		{"hs", SigningMethodHS256, []byte("secret")},
+	}
+	p := NewParser(WithKeys(keys...))
+
+	t.Run("alg none", func(t *testing.T) {
+		token := encodeSegment([]byte(`{"alg":"none","kid":"hs"}`)) + "." + encodeSegment([]byte(`{}`)) + "."
+		_, err := p.Parse(token, &RegisteredClaims{})
+		require.ErrorIs(t, err, ErrAlgorithmNone)
+	})
+	t.Run("algorithm confusion", func(t *testing.T) {
+		// 使用 rsa 公钥作为 hmac 密钥签名, 并指定 rsa 的 kid
+		token, err := Sign(&Key{"rsa", SigningMethodHS256, []byte("public key bytes")}, &RegisteredClaims{})
+		require.NoError(t, err)
+		_, err = p.Parse(token, &RegisteredClaims{})
+		require.ErrorIs(t, err, ErrAlgorithmMismatch)
+	})
+	t.Run("key type mismatch", func(t *testing.T) {
+		_, err := NewParser(WithKeys(&Key{"rsa", SigningMethodHS256, &rsaKey.PublicKey})).
+			Parse(mustSign(t, &Key{"rsa", SigningMethodHS256, []byte("x")}, &RegisteredClaims{}), &RegisteredClaims{})
+		require.ErrorIs(t, err, ErrInvalidKeyType)
+	})
+	t.Run("unknown kid", func(t *testing.T) {
+		_, err := p.Parse(mustSign(t, &Key{"unknown", SigningMethodHS256, []byte("secret")}, &RegisteredClaims{}), &RegisteredClaims{})
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+	t.Run("wrong secret", func(t *testing.T) {
+		_, err := p.Parse(mustSign(t, &Key{"hs", SigningMethodHS256, []byte("other")}, &RegisteredClaims{}), &RegisteredClaims{})
+		require.ErrorIs(t, err, ErrSignatureInvalid)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		for _, token := range []string{
+			"",
+			"a.b",
+			"!!.b.c",
+			base64.RawURLEncoding.EncodeToString([]byte("{")) + ".b.c",
+			strings.Repeat(".", 3),
+		} {
+			_, err := p.Parse(token, &RegisteredClaims{})
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestParseClaims(t *testing.T) {
+	key := &Key{"", SigningMethodHS256, []byte("secret")}
+	now := time.Unix(1700000000, 0)
+	timeFunc := func() time.Time { return now }
+
+	tests := []struct {
+		name   string
+		claims RegisteredClaims
+		opts   []Option
+		err    error
+	}{
+		{"valid", RegisteredClaims{ExpiresAt: NumericDate(now.Unix() + 1)}, nil, nil},
+		{"expired", RegisteredClaims{ExpiresAt: NumericDate(now.Unix() - 10)}, nil, ErrTokenExpired},
+		{"expired within leeway", RegisteredClaims{ExpiresAt: NumericDate(now.Unix() - 10)}, []Option{WithLeeway(time.Minute)}, nil},
+		{"not valid yet", RegisteredClaims{NotBefore: NumericDate(now.Unix() + 10)}, nil, ErrTokenNotValidYet},
+		{"not valid yet within leeway", RegisteredClaims{NotBefore: NumericDate(now.Unix() + 10)}, []Option{WithLeeway(time.Minute)}, nil},
+		{"used before issued", RegisteredClaims{IssuedAt: NumericDate(now.Unix() + 10)}, nil, ErrTokenUsedBeforeIssued},
+		{"required exp", RegisteredClaims{}, []Option{WithExpirationRequired()}, ErrTokenRequiredClaim},
+		{"invalid issuer", RegisteredClaims{Issuer: "a"}, []Option{WithIssuer("b")}, ErrTokenInvalidIssuer},
+		{"invalid audience", RegisteredClaims{Audience: Audience{"a", "b"}}, []Option{WithAudience("c")}, ErrTokenInvalidAudience},
+		{"valid audience", RegisteredClaims{Audience: Audience{"a", "b"}}, []Option{WithAudience("b")}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := mustSign(t, key, &tt.claims)
+			opts := append([]Option{WithKeys(key), WithTimeFunc(timeFunc)}, tt.opts...)
+			_, err := NewParser(opts...).Parse(token, &RegisteredClaims{})
+			if tt.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNumericDate(t *testing.T) {
+	var c RegisteredClaims
+	require.NoError(t, json.Unmarshal([]byte(`{"exp": 1700000000.5, "nbf": 1.6e9, "iat": 1600000000}`), &c))
+	require.Equal(t, NumericDate(1700000000), c.ExpiresAt)
+	require.Equal(t, NumericDate(1600000000), c.NotBefore)
+	require.Equal(t, NumericDate(1600000000), c.IssuedAt)
+	require.Equal(t, time.Unix(1700000000, 0), c.ExpiresAt.Time())
+	require.NoError(t, json.Unmarshal([]byte(`{"exp": null}`), &c))
+	for _, s := range []string{`{"exp": "1700000000"}`, `{"exp": 1e100}`, `{"exp": true}`} {
+		require.Error(t, json.Unmarshal([]byte(s), &c), s)
+	}
+
+	b, err := json.Marshal(&RegisteredClaims{ExpiresAt: 1700000000})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"exp": 1700000000}`, string(b))
+
+	// 带小数的时间
+	key := &Key{"", SigningMethodHS256, []byte("secret")}
+	token := mustSign(t, key, map[string]any{"exp": 1700000000.5})
+	now := func() time.Time { return time.Unix(1700000000, 0) }
+	_, err = NewParser(WithKeys(key), WithTimeFunc(now)).Parse(token, &RegisteredClaims{})
+	require.NoError(t, err)
+}
+
+func TestAudience(t *testing.T) {
+	var a Audience
+	require.NoError(t, a.UnmarshalJSON([]byte(`"a"`)))
+	require.Equal(t, Audience{"a"}, a)
+	require.NoError(t, a.UnmarshalJSON([]byte(`["a","b"]`)))
+	require.Equal(t, Audience{"a", "b"}, a)
+	require.Error(t, a.UnmarshalJSON([]byte(`1`)))
+
+	b, err := Audience{"a"}.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `"a"`, string(b))
+	b, err = Audience{"a", "b"}.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, `["a","b"]`, string(b))
+}
+
+func mustSign(t *testing.T, key *Key, claims any) string {
+	token, err := Sign(key, claims)
+	require.NoError(t, err)
+	return token
+}
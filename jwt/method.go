@@ -0,0 +1,145 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+
+	"github.com/things-go/clip/signature"
+)
+
+// SigningMethod jws 签名算法.
+// Sign 和 Verify 会校验密钥类型, 以防止算法混淆攻击.
+type SigningMethod interface {
+	// Alg 算法名称, 对应 header 中的 alg
+	Alg() string
+	// Sign 签名, key 为 []byte, *rsa.PrivateKey 或 *ecdsa.PrivateKey
+	Sign(data []byte, key any) ([]byte, error)
+	// Verify 验签, key 为 []byte, *rsa.PublicKey 或 *ecdsa.PublicKey, 签名正确返回 nil
+	Verify(data, sig []byte, key any) error
+}
+
+// signing method defined
+var (
+	SigningMethodHS256 SigningMethod = &hmacMethod{"HS256", crypto.SHA256}
+	SigningMethodHS384 SigningMethod = &hmacMethod{"HS384", crypto.SHA384}
+	SigningMethodHS512 SigningMethod = &hmacMethod{"HS512", crypto.SHA512}
+	SigningMethodRS256 SigningMethod = &rsaMethod{"RS256", crypto.SHA256, false}
+	SigningMethodRS384 SigningMethod = &rsaMethod{"RS384", crypto.SHA384, false}
+	SigningMethodRS512 SigningMethod = &rsaMethod{"RS512", crypto.SHA512, false}
+	SigningMethodPS256 SigningMethod = &rsaMethod{"PS256", crypto.SHA256, true}
+	SigningMethodPS384 SigningMethod = &rsaMethod{"PS384", crypto.SHA384, true}
+	SigningMethodPS512 SigningMethod = &rsaMethod{"PS512", crypto.SHA512, true}
+	SigningMethodES256 SigningMethod = &ecdsaMethod{"ES256", crypto.SHA256, 256}
+	SigningMethodES384 SigningMethod = &ecdsaMethod{"ES384", crypto.SHA384, 384}
+	SigningMethodES512 SigningMethod = &ecdsaMethod{"ES512", crypto.SHA512, 521}
+)
+
+var signingMethods = map[string]SigningMethod{}
+
+func init() {
+	for _, m := range []SigningMethod{
+		SigningMethodHS256, SigningMethodHS384, SigningMethodHS512,
+		SigningMethodRS256, SigningMethodRS384, SigningMethodRS512,
+		SigningMethodPS256, SigningMethodPS384, SigningMethodPS512,
+		SigningMethodES256, SigningMethodES384, SigningMethodES512,
+	} {
+		signingMethods[m.Alg()] = m
+	}
+}
+
+// GetSigningMethod 根据算法名称获取签名算法, 不支持 "none".
+func GetSigningMethod(alg string) (SigningMethod, bool) {
+	m, ok := signingMethods[alg]
+	return m, ok
+}
+
+type hmacMethod struct {
+	alg  string
+	hash crypto.Hash
+}
+
+func (m *hmacMethod) Alg() string { return m.alg }
+
+func (m *hmacMethod) Sign(data []byte, key any) ([]byte, error) {
+	k, ok := key.([]byte)
+	if !ok || len(k) == 0 {
+		return nil, ErrInvalidKeyType
+	}
+	return signature.Hmac(m.hash.New, k, data), nil
+}
+
+func (m *hmacMethod) Verify(data, sig []byte, key any) error {
+	k, ok := key.([]byte)
+	if !ok || len(k) == 0 {
+		return ErrInvalidKeyType
+	}
+	if !hmac.Equal(sig, signature.Hmac(m.hash.New, k, data)) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+type rsaMethod struct {
+	alg  string
+	hash crypto.Hash
+	pss  bool
+}
+
+func (m *rsaMethod) Alg() string { return m.alg }
+
+func (m *rsaMethod) Sign(data []byte, key any) ([]byte, error) {
+	k, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidKeyType
+	}
+	if m.pss {
+		return signature.RsaSignPSS(k, m.hash, data)
+	}
+	return signature.RsaSignPKCS1v15(k, m.hash, data)
+}
+
+func (m *rsaMethod) Verify(data, sig []byte, key any) error {
+	k, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return ErrInvalidKeyType
+	}
+	var err error
+	if m.pss {
+		err = signature.RsaVerifyPSS(k, m.hash, data, sig)
+	} else {
+		err = signature.RsaVerifyPKCS1v15(k, m.hash, data, sig)
+	}
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+type ecdsaMethod struct {
+	alg     string
+	hash    crypto.Hash
+	bitSize int
+}
+
+func (m *ecdsaMethod) Alg() string { return m.alg }
+
+func (m *ecdsaMethod) Sign(data []byte, key any) ([]byte, error) {
+	k, ok := key.(*ecdsa.PrivateKey)
+	if !ok || k.Curve.Params().BitSize != m.bitSize {
+		return nil, ErrInvalidKeyType
+	}
+	return signature.EcdsaSign(k, m.hash, data)
+}
+
+func (m *ecdsaMethod) Verify(data, sig []byte, key any) error {
+	k, ok := key.(*ecdsa.PublicKey)
+	if !ok || k.Curve.Params().BitSize != m.bitSize {
+		return ErrInvalidKeyType
+	}
+	if signature.EcdsaVerify(k, m.hash, data, sig) != nil {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package jwt
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/things-go/clip/lookup"
+)
+
+type ctxClaimsKey struct{}
+
+// NewContext put claims into context
+func NewContext(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, ctxClaimsKey{}, claims)
+}
+
+// FromContext get claims from context
+func FromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(ctxClaimsKey{}).(Claims)
+	return claims, ok
+}
+
+// ParseFromRequest 使用 lk 从请求中提取 token, 并解析到 claims.
+func (p *Parser) ParseFromRequest(r *http.Request, lk *lookup.Lookup, claims Claims) (*Header, error) {
+	token, err := lk.ExtractToken(r)
+	if err != nil {
+		return nil, err
+	}
+	return p.Parse(token, claims)
+}
+
+// Middleware 提取并校验 token, 成功后将 claims 放入请求的 context 中, 通过 FromContext 获取.
+// newClaims 每次请求新建一个 claims 对象.
+// errHandler 校验失败时调用, 为 nil 时返回 401.
+func Middleware(p *Parser, lk *lookup.Lookup, newClaims func() Claims, errHandler func(http.ResponseWriter, *http.Request, error)) func(http.Handler) http.Handler {
+	if errHandler == nil {
+		errHandler = func(w http.ResponseWriter, _ *http.Request, _ error) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := newClaims()
+			if _, err := p.ParseFromRequest(r, lk, claims); err != nil {
+				errHandler(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), claims)))
+		})
+	}
+}
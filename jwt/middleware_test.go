@@ -0,0 +1,49 @@
+package jwt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/things-go/clip/lookup"
+)
+
+func TestMiddleware(t *testing.T) {
+	key := &Key{"k1", SigningMethodHS256, []byte("secret")}
+	token := mustSign(t, key, &testClaims{
+		RegisteredClaims: RegisteredClaims{ExpiresAt: NewNumericDate(time.Now().Add(time.Hour))},
+		Name:             "jjl",
+	})
+
+	handler := Middleware(
+		NewParser(WithKeys(key)),
+		lookup.NewLookup(""),
+		func() Claims { return &testClaims{} },
+		nil,
+	)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := FromContext(r.Context())
+		require.True(t, ok)
+		_, _ = w.Write([]byte(claims.(*testClaims).Name))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "jjl", w.Body.String())
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token+"x")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
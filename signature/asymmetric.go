@@ -0,0 +1,96 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"math/big"
+)
+
+// error defined
+var (
+	ErrHashUnavailable       = errors.New("the requested hash function is unavailable")
+	ErrEcdsaInvalidSignature = errors.New("ecdsa signature is invalid")
+)
+
+// RsaSignPKCS1v15 rsa PKCS #1 v1.5 签名, data 先经过 hash 计算摘要.
+func RsaSignPKCS1v15(pri *rsa.PrivateKey, hash crypto.Hash, data []byte) ([]byte, error) {
+	digest, err := hashSum(hash, data)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.SignPKCS1v15(rand.Reader, pri, hash, digest)
+}
+
+// RsaVerifyPKCS1v15 rsa PKCS #1 v1.5 验签, 签名正确返回 nil.
+func RsaVerifyPKCS1v15(pub *rsa.PublicKey, hash crypto.Hash, data, sig []byte) error {
+	digest, err := hashSum(hash, data)
+	if err != nil {
+		return err
+	}
+	return rsa.VerifyPKCS1v15(pub, hash, digest, sig)
+}
+
+// RsaSignPSS rsa PSS 签名, salt 长度与 hash 长度相同.
+func RsaSignPSS(pri *rsa.PrivateKey, hash crypto.Hash, data []byte) ([]byte, error) {
+	digest, err := hashSum(hash, data)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.SignPSS(rand.Reader, pri, hash, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+}
+
+// RsaVerifyPSS rsa PSS 验签, 签名正确返回 nil.
+func RsaVerifyPSS(pub *rsa.PublicKey, hash crypto.Hash, data, sig []byte) error {
+	digest, err := hashSum(hash, data)
+	if err != nil {
+		return err
+	}
+	return rsa.VerifyPSS(pub, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+}
+
+// EcdsaSign ecdsa 签名, 签名格式为定长的 r || s (RFC 7518 3.4).
+func EcdsaSign(pri *ecdsa.PrivateKey, hash crypto.Hash, data []byte) ([]byte, error) {
+	digest, err := hashSum(hash, data)
+	if err != nil {
+		return nil, err
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, pri, digest)
+	if err != nil {
+		return nil, err
+	}
+	size := (pri.Curve.Params().BitSize + 7) / 8
+	out := make([]byte, 2*size)
+	r.FillBytes(out[:size])
+	s.FillBytes(out[size:])
+	return out, nil
+}
+
+// EcdsaVerify ecdsa 验签, 签名格式为定长的 r || s (RFC 7518 3.4), 签名正确返回 nil.
+func EcdsaVerify(pub *ecdsa.PublicKey, hash crypto.Hash, data, sig []byte) error {
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	if len(sig) != 2*size {
+		return ErrEcdsaInvalidSignature
+	}
+	digest, err := hashSum(hash, data)
+	if err != nil {
+		return err
+	}
+	r := new(big.Int).SetBytes(sig[:size])
+	s := new(big.Int).SetBytes(sig[size:])
+	if !ecdsa.Verify(pub, digest, r, s) {
+		return ErrEcdsaInvalidSignature
+	}
+	return nil
+}
+
+func hashSum(hash crypto.Hash, data []byte) ([]byte, error) {
+	if !hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+	h := hash.New()
+	h.Write(data)
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,61 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHmac(t *testing.T) {
+	require.Equal(t, HmacSha256("key", "data"), EncodingBase64.EncodeToString(Hmac(sha256.New, []byte("key"), []byte("data"))))
+}
+
+func TestRsaSignVerify(t *testing.T) {
+	priKey, err := ParseRSAPrivateKeyFromPEM([]byte(pri))
+	require.NoError(t, err)
+	pubKey, err := ParseRSAPublicKeyFromPEM([]byte(pub))
+	require.NoError(t, err)
+
+	data := []byte("helloworld,this is golang language. welcome")
+
+	sig, err := RsaSignPKCS1v15(priKey, crypto.SHA256, data)
+	require.NoError(t, err)
+	require.NoError(t, RsaVerifyPKCS1v15(pubKey, crypto.SHA256, data, sig))
+	require.Error(t, RsaVerifyPKCS1v15(pubKey, crypto.SHA256, []byte("other"), sig))
+
+	sig, err = RsaSignPSS(priKey, crypto.SHA384, data)
+	require.NoError(t, err)
+	require.NoError(t, RsaVerifyPSS(pubKey, crypto.SHA384, data, sig))
+	require.Error(t, RsaVerifyPSS(pubKey, crypto.SHA384, []byte("other"), sig))
+
+	_, err = RsaSignPKCS1v15(priKey, crypto.Hash(0), data)
+	require.ErrorIs(t, err, ErrHashUnavailable)
+}
+
+func TestEcdsaSignVerify(t *testing.T) {
+	data := []byte("helloworld,this is golang language. welcome")
+	for _, tt := range []struct {
+		curve elliptic.Curve
+		hash  crypto.Hash
+		size  int
+	}{
+		{elliptic.P256(), crypto.SHA256, 64},
+		{elliptic.P384(), crypto.SHA384, 96},
+		{elliptic.P521(), crypto.SHA512, 132},
+	} {
+		key, err := ecdsa.GenerateKey(tt.curve, rand.Reader)
+		require.NoError(t, err)
+
+		sig, err := EcdsaSign(key, tt.hash, data)
+		require.NoError(t, err)
+		require.Len(t, sig, tt.size)
+		require.NoError(t, EcdsaVerify(&key.PublicKey, tt.hash, data, sig))
+		require.ErrorIs(t, EcdsaVerify(&key.PublicKey, tt.hash, []byte("other"), sig), ErrEcdsaInvalidSignature)
+		require.ErrorIs(t, EcdsaVerify(&key.PublicKey, tt.hash, data, sig[1:]), ErrEcdsaInvalidSignature)
+	}
+}
@@ -0,0 +1,108 @@
+package signature
+
+import (
+	"crypto/sha256"
+	"hash"
+	"strings"
+	"unicode"
+)
+
+// BlindIndexOption blind index option
+type BlindIndexOption func(*BlindIndex)
+
+// WithBlindIndexHash hmac 的哈希函数, 默认 sha256.New
+func WithBlindIndexHash(h func() hash.Hash) BlindIndexOption {
+	return func(b *BlindIndex) {
+		b.hash = h
+	}
+}
+
+// WithBlindIndexBits 截断索引到 bits 位, 默认不截断.
+// 截断会增加碰撞, 查询时需要解密比对, 但可以降低通过索引推测明文的风险.
+func WithBlindIndexBits(bits int) BlindIndexOption {
+	return func(b *BlindIndex) {
+		b.bits = bits
+	}
+}
+
+// WithBlindIndexEncoding 索引的编码方式, 默认 EncodingHex
+func WithBlindIndexEncoding(enc Encoding) BlindIndexOption {
+	return func(b *BlindIndex) {
+		b.encoding = enc
+	}
+}
+
+// WithBlindIndexNormalizer 计算索引前依次对值进行规范化, 如 NormalizeEmail, NormalizeDigits.
+func WithBlindIndexNormalizer(fns ...func(string) string) BlindIndexOption {
+	return func(b *BlindIndex) {
+		b.normalizers = append(b.normalizers, fns...)
+	}
+}
+
+// BlindIndex 基于 hmac 的盲索引, 加密字段另存一列索引, 可以不解密进行等值查询.
+// 不同字段应使用不同的 key.
+type BlindIndex struct {
+	key         []byte
+	hash        func() hash.Hash
+	bits        int
+	encoding    Encoding
+	normalizers []func(string) string
+}
+
+// NewBlindIndex 新建盲索引生成器
+func NewBlindIndex(key string, opts ...BlindIndexOption) *BlindIndex {
+	b := &BlindIndex{
+		key:      []byte(key),
+		hash:     sha256.New,
+		encoding: EncodingHex,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Index 计算值的盲索引
+func (b *BlindIndex) Index(value string) string {
+	for _, f := range b.normalizers {
+		value = f(value)
+	}
+	sum := Hmac(b.hash, b.key, []byte(value))
+	if b.bits > 0 && b.bits < len(sum)*8 {
+		sum = sum[:(b.bits+7)/8]
+		if r := b.bits % 8; r != 0 {
+			sum[len(sum)-1] &= byte(0xff << (8 - r))
+		}
+	}
+	return b.encoding.EncodeToString(sum)
+}
+
+// NormalizeLower 去除首尾空白并转小写
+func NormalizeLower(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// NormalizeEmail 去除首尾空白并转小写
+func NormalizeEmail(s string) string {
+	return NormalizeLower(s)
+}
+
+// NormalizeDigits 仅保留数字, 如去除电话号码中的空格, '-', '+', 括号等.
+func NormalizeDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// NormalizeSpace 去除所有空白
+func NormalizeSpace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
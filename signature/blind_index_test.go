@@ -0,0 +1,33 @@
+package signature
+
+import (
+	"crypto/sha512"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlindIndex(t *testing.T) {
+	b := NewBlindIndex("email-index-key", WithBlindIndexNormalizer(NormalizeEmail))
+	require.Equal(t, b.Index("JJL@Example.com "), b.Index("jjl@example.com"))
+	require.NotEqual(t, b.Index("jjl@example.com"), b.Index("jjl@example.org"))
+	require.Len(t, b.Index("jjl@example.com"), 64)
+	require.NotEqual(t, b.Index("jjl@example.com"), NewBlindIndex("other-key").Index("jjl@example.com"))
+
+	phone := NewBlindIndex("phone-index-key",
+		WithBlindIndexNormalizer(NormalizeSpace, NormalizeDigits),
+		WithBlindIndexBits(20),
+		WithBlindIndexHash(sha512.New),
+	)
+	idx := phone.Index("137-0597 0181")
+	require.Equal(t, idx, phone.Index("(137) 0597-0181"))
+	require.Len(t, idx, 6)
+	require.Equal(t, byte('0'), idx[5]) // 低 4 位被清除
+
+	b64 := NewBlindIndex("key", WithBlindIndexEncoding(EncodingBase64URL), WithBlindIndexBits(32))
+	require.Len(t, b64.Index("v"), 6)
+
+	require.Equal(t, "abc", NormalizeLower(" ABC "))
+	require.Equal(t, "8613705970181", NormalizeDigits("+86 137-0597-0181"))
+	require.Equal(t, "abc", NormalizeSpace(" a b\tc\n"))
+}
@@ -0,0 +1,267 @@
+package signature
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+const (
+	envelopeVersion = 1
+	dataKeySize     = 32
+)
+
+// error defined
+var (
+	ErrEnvelopeMalformed  = errors.New("envelope: blob is malformed")
+	ErrEnvelopeVersion    = errors.New("envelope: unsupported version")
+	ErrMasterKeyNotFound  = errors.New("envelope: master key not found")
+	ErrMasterKeyInvalidID = errors.New("envelope: master key id length must be in [1, 255]")
+	ErrMasterKeyNoPrivate = errors.New("envelope: rsa master key has no private key")
+	ErrMasterKeyNoPublic  = errors.New("envelope: rsa master key has no public key")
+)
+
+// MasterKey 主密钥, 用于包装/解包数据密钥.
+type MasterKey interface {
+	// ID 主密钥标识, 写入密文中, 解密时用于查找主密钥.
+	ID() string
+	// WrapKey 包装数据密钥
+	WrapKey(dataKey []byte) ([]byte, error)
+	// UnwrapKey 解包数据密钥
+	UnwrapKey(wrapped []byte) ([]byte, error)
+}
+
+// MasterKeyResolver 根据 ID 查找主密钥
+type MasterKeyResolver interface {
+	MasterKey(id string) (MasterKey, error)
+}
+
+// aesMasterKey 本地 aes 主密钥, 使用 aes-gcm 包装数据密钥.
+type aesMasterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewAesMasterKey 本地 aes 主密钥, key must one of 16, 24, 32
+func NewAesMasterKey(id string, key []byte) (MasterKey, error) {
+	if len(id) == 0 || len(id) > 255 {
+		return nil, ErrMasterKeyInvalidID
+	}
+	aead, err := newAesGcm(key)
+	if err != nil {
+		return nil, err
+	}
+	return &aesMasterKey{id, aead}, nil
+}
+
+func (k *aesMasterKey) ID() string { return k.id }
+
+func (k *aesMasterKey) WrapKey(dataKey []byte) ([]byte, error) {
+	return gcmSeal(k.aead, dataKey, []byte(k.id))
+}
+
+func (k *aesMasterKey) UnwrapKey(wrapped []byte) ([]byte, error) {
+	return gcmOpen(k.aead, wrapped, []byte(k.id))
+}
+
+// rsaMasterKey rsa 主密钥, 使用 RsaEncrypt 包装数据密钥.
+type rsaMasterKey struct {
+	id  string
+	pub *rsa.PublicKey
+	pri *rsa.PrivateKey
+}
+
+// NewRsaMasterKey rsa 主密钥, 仅加密时 pri 可以为 nil, pub 为 nil 时使用 pri 的公钥, 都为 nil 时返回 ErrMasterKeyNoPublic.
+func NewRsaMasterKey(id string, pub *rsa.PublicKey, pri *rsa.PrivateKey) (MasterKey, error) {
+	if len(id) == 0 || len(id) > 255 {
+		return nil, ErrMasterKeyInvalidID
+	}
+	if pub == nil {
+		if pri == nil {
+			return nil, ErrMasterKeyNoPublic
+		}
+		pub = &pri.PublicKey
+	}
+	return &rsaMasterKey{id, pub, pri}, nil
+}
+
+func (k *rsaMasterKey) ID() string { return k.id }
+
+func (k *rsaMasterKey) WrapKey(dataKey []byte) ([]byte, error) {
+	if k.pub == nil {
+		return nil, ErrMasterKeyNoPublic
+	}
+	wrapped, err := RsaEncrypt(k.pub, string(dataKey))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(wrapped), nil
+}
+
+func (k *rsaMasterKey) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if k.pri == nil {
+		return nil, ErrMasterKeyNoPrivate
+	}
+	dataKey, err := RsaDecrypt(k.pri, string(wrapped))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(dataKey), nil
+}
+
+// LocalKMS 进程内的 KMS 替身, 管理主密钥, 用于测试或无外部 KMS 服务的场景.
+type LocalKMS struct {
+	mu   sync.RWMutex
+	keys map[string]MasterKey
+}
+
+// NewLocalKMS 新建进程内 KMS
+func NewLocalKMS(keys ...MasterKey) *LocalKMS {
+	kms := &LocalKMS{keys: make(map[string]MasterKey)}
+	for _, k := range keys {
+		kms.Add(k)
+	}
+	return kms
+}
+
+// Add 添加主密钥
+func (kms *LocalKMS) Add(key MasterKey) {
+	kms.mu.Lock()
+	defer kms.mu.Unlock()
+	kms.keys[key.ID()] = key
+}
+
+// GenerateMasterKey 生成随机的 aes-256 主密钥, 并添加到 KMS 中.
+func (kms *LocalKMS) GenerateMasterKey(id string) (MasterKey, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, err
+	}
+	mk, err := NewAesMasterKey(id, key)
+	if err != nil {
+		return nil, err
+	}
+	kms.Add(mk)
+	return mk, nil
+}
+
+// MasterKey implement MasterKeyResolver
+func (kms *LocalKMS) MasterKey(id string) (MasterKey, error) {
+	kms.mu.RLock()
+	defer kms.mu.RUnlock()
+	key, ok := kms.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrMasterKeyNotFound, id)
+	}
+	return key, nil
+}
+
+// EnvelopeEncrypt 信封加密, 每次生成随机的数据密钥, 使用 aes-256-gcm 加密数据, 并用主密钥包装数据密钥.
+// aad 为附加认证数据, 如记录 ID 或列名, 解密时必须一致, 可以为 nil.
+// 输出为自描述的 base64 编码:
+//
+//	version(1) | keyIDLen(1) | keyID | wrappedLen(2) | wrappedKey | nonce(12) | ciphertext+tag
+func EnvelopeEncrypt(mk MasterKey, plaintext, aad []byte) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+	wrapped, err := mk.WrapKey(dataKey)
+	if err != nil {
+		return "", err
+	}
+	id := mk.ID()
+	if len(id) == 0 || len(id) > 255 {
+		return "", ErrMasterKeyInvalidID
+	}
+	if len(wrapped) > 0xffff {
+		return "", ErrEnvelopeMalformed
+	}
+
+	header := make([]byte, 0, 4+len(id)+len(wrapped))
+	header = append(header, envelopeVersion, byte(len(id)))
+	header = append(header, id...)
+	header = append(header, byte(len(wrapped)>>8), byte(len(wrapped)))
+	header = append(header, wrapped...)
+
+	aead, err := newAesGcm(dataKey)
+	if err != nil {
+		return "", err
+	}
+	body, err := gcmSeal(aead, plaintext, append(header[:len(header):len(header)], aad...))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(append(header, body...)), nil
+}
+
+// EnvelopeDecrypt 信封解密, 根据密文中的主密钥 ID 查找主密钥解包数据密钥, 再解密数据.
+func EnvelopeDecrypt(resolver MasterKeyResolver, blob string, aad []byte) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(blob)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 2 {
+		return nil, ErrEnvelopeMalformed
+	}
+	if b[0] != envelopeVersion {
+		return nil, ErrEnvelopeVersion
+	}
+	idEnd := 2 + int(b[1])
+	if len(b) < idEnd+2 {
+		return nil, ErrEnvelopeMalformed
+	}
+	id := string(b[2:idEnd])
+	wrappedEnd := idEnd + 2 + int(binary.BigEndian.Uint16(b[idEnd:]))
+	if len(b) < wrappedEnd {
+		return nil, ErrEnvelopeMalformed
+	}
+	header, body := b[:wrappedEnd], b[wrappedEnd:]
+
+	mk, err := resolver.MasterKey(id)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := mk.UnwrapKey(header[idEnd+2:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAesGcm(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return gcmOpen(aead, body, append(header[:len(header):len(header)], aad...))
+}
+
+func newAesGcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// gcmSeal 返回 nonce + ciphertext
+func gcmSeal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// gcmOpen 解密 nonce + ciphertext
+func gcmOpen(aead cipher.AEAD, cipherText, aad []byte) ([]byte, error) {
+	if len(cipherText) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrEnvelopeMalformed
+	}
+	nonce, body := cipherText[:aead.NonceSize()], cipherText[aead.NonceSize():]
+	return aead.Open(nil, nonce, body, aad)
+}
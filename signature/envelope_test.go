@@ -0,0 +1,93 @@
+package signature
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	priKey, err := ParseRSAPrivateKeyFromPEM([]byte(pri))
+	require.NoError(t, err)
+	pubKey, err := ParseRSAPublicKeyFromPEM([]byte(pub))
+	require.NoError(t, err)
+
+	kms := NewLocalKMS()
+	aesKey, err := kms.GenerateMasterKey("aes-v1")
+	require.NoError(t, err)
+	rsaKey, err := NewRsaMasterKey("rsa-v1", nil, priKey)
+	require.NoError(t, err)
+	kms.Add(rsaKey)
+
+	plainText := []byte("350102199003077777")
+	aad := []byte("users.id_number:10086")
+
+	for _, mk := range []MasterKey{aesKey, rsaKey} {
+		t.Run(mk.ID(), func(t *testing.T) {
+			blob1, err := EnvelopeEncrypt(mk, plainText, aad)
+			require.NoError(t, err)
+			blob2, err := EnvelopeEncrypt(mk, plainText, aad)
+			require.NoError(t, err)
+			require.NotEqual(t, blob1, blob2)
+
+			got, err := EnvelopeDecrypt(kms, blob1, aad)
+			require.NoError(t, err)
+			require.Equal(t, plainText, got)
+
+			_, err = EnvelopeDecrypt(kms, blob1, []byte("users.id_number:10087"))
+			require.Error(t, err)
+
+			b, err := base64.StdEncoding.DecodeString(blob1)
+			require.NoError(t, err)
+			b[len(b)-1] ^= 0xff
+			_, err = EnvelopeDecrypt(kms, base64.StdEncoding.EncodeToString(b), aad)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("rsa public key only", func(t *testing.T) {
+		encOnly, err := NewRsaMasterKey("rsa-v1", pubKey, nil)
+		require.NoError(t, err)
+		blob, err := EnvelopeEncrypt(encOnly, plainText, nil)
+		require.NoError(t, err)
+		_, err = EnvelopeDecrypt(NewLocalKMS(encOnly), blob, nil)
+		require.ErrorIs(t, err, ErrMasterKeyNoPrivate)
+		got, err := EnvelopeDecrypt(kms, blob, nil)
+		require.NoError(t, err)
+		require.Equal(t, plainText, got)
+	})
+	t.Run("master key not found", func(t *testing.T) {
+		blob, err := EnvelopeEncrypt(aesKey, plainText, nil)
+		require.NoError(t, err)
+		_, err = EnvelopeDecrypt(NewLocalKMS(), blob, nil)
+		require.ErrorIs(t, err, ErrMasterKeyNotFound)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		for _, b := range [][]byte{{}, {envelopeVersion}, {envelopeVersion, 10, 'a'}, {envelopeVersion, 1, 'a', 0xff, 0xff}} {
+			_, err := EnvelopeDecrypt(kms, base64.StdEncoding.EncodeToString(b), nil)
+			require.ErrorIs(t, err, ErrEnvelopeMalformed)
+		}
+		_, err := EnvelopeDecrypt(kms, base64.StdEncoding.EncodeToString([]byte{2, 0}), nil)
+		require.ErrorIs(t, err, ErrEnvelopeVersion)
+	})
+	t.Run("invalid master key", func(t *testing.T) {
+		key := make([]byte, 32)
+		_, err := io.ReadFull(rand.Reader, key)
+		require.NoError(t, err)
+		_, err = NewAesMasterKey("", key)
+		require.ErrorIs(t, err, ErrMasterKeyInvalidID)
+		_, err = NewAesMasterKey("k", key[:10])
+		require.Error(t, err)
+
+		_, err = NewRsaMasterKey("k", nil, nil)
+		require.ErrorIs(t, err, ErrMasterKeyNoPublic)
+		empty := &rsaMasterKey{id: "k"}
+		_, err = empty.WrapKey(key)
+		require.ErrorIs(t, err, ErrMasterKeyNoPublic)
+		_, err = empty.UnwrapKey(key)
+		require.ErrorIs(t, err, ErrMasterKeyNoPrivate)
+	})
+}
@@ -7,8 +7,16 @@ import (
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/hex"
+	"hash"
 )
 
+// Hmac hmac with the hash function, return the raw digest.
+func Hmac(h func() hash.Hash, key, data []byte) []byte {
+	mac := hmac.New(h, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
 // HmacSha1 hmac sha1 with base64 encoded.
 func HmacSha1(key, str string) string {
 	h := hmac.New(sha1.New, []byte(key))
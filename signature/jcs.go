@@ -0,0 +1,220 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrJCSInvalidNumber number can not be represented in I-JSON, like NaN, ±Infinity.
+var ErrJCSInvalidNumber = errors.New("jcs: number is not a valid I-JSON number")
+
+// SignJSON 对象以 RFC 8785 (JCS) 规范化后签名.
+// v 可以是任意可 json 序列化的对象, 原始 json 请使用 json.RawMessage.
+func SignJSON(v any, secret string, sign func(string) string) (string, error) {
+	b, err := MarshalCanonicalJSON(v)
+	if err != nil {
+		return "", err
+	}
+	return sign(string(b) + secret), nil
+}
+
+// VerifyJSON 验证对象以 RFC 8785 (JCS) 规范化后的签名是否正确
+func VerifyJSON(v any, secret, targetSign string, sign func(string) string) bool {
+	got, err := SignJSON(v, secret, sign)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(targetSign), []byte(got)) == 1
+}
+
+// MarshalCanonicalJSON 对象序列化为 RFC 8785 (JCS) 规范化的 json.
+func MarshalCanonicalJSON(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return CanonicalJSON(b)
+}
+
+// CanonicalJSON 原始 json 转换为 RFC 8785 (JCS) 规范化的 json.
+// 对象的 key 按 UTF-16 编码单元排序, 数字按 ECMAScript 规则格式化, 无多余空白.
+func CanonicalJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("jcs: invalid character after top-level value")
+	}
+	buf := &bytes.Buffer{}
+	if err := writeCanonical(buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, v any) error {
+	switch vv := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(vv))
+	case string:
+		writeCanonicalString(buf, vv)
+	case json.Number:
+		f, err := strconv.ParseFloat(string(vv), 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrJCSInvalidNumber, vv)
+		}
+		s, err := FormatJSONNumber(f)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(s)
+	case []any:
+		buf.WriteByte('[')
+		for i, e := range vv {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(vv))
+		for k := range vv {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalString(buf, k)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, vv[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("jcs: unsupported type %T", v)
+	}
+	return nil
+}
+
+// writeCanonicalString 按 ECMAScript JSON.stringify 规则转义字符串
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			buf.WriteRune(r)
+			i += size
+			continue
+		}
+		switch c {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if c < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[c>>4])
+				buf.WriteByte(hex[c&0xf])
+			} else {
+				buf.WriteByte(c)
+			}
+		}
+		i++
+	}
+	buf.WriteByte('"')
+}
+
+// lessUTF16 按 UTF-16 编码单元比较字符串
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// FormatJSONNumber 按 ECMAScript Number.prototype.toString 规则格式化数字.
+// NaN 和 ±Infinity 返回 ErrJCSInvalidNumber.
+func FormatJSONNumber(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", ErrJCSInvalidNumber
+	}
+	if f == 0 { // 包括 -0
+		return "0", nil
+	}
+
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+	// 最短可还原的有效数字及指数, 格式: d.ddde±xx
+	es := strconv.FormatFloat(f, 'e', -1, 64)
+	idx := strings.IndexByte(es, 'e')
+	exp, _ := strconv.Atoi(es[idx+1:])
+	digits := strings.Replace(es[:idx], ".", "", 1)
+
+	k := len(digits)
+	n := exp + 1 // 小数点的位置
+	var s string
+	switch {
+	case k <= n && n <= 21:
+		s = digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		s = digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		s = "0." + strings.Repeat("0", -n) + digits
+	default:
+		s = digits[:1]
+		if k > 1 {
+			s += "." + digits[1:]
+		}
+		if n-1 >= 0 {
+			s += "e+" + strconv.Itoa(n-1)
+		} else {
+			s += "e" + strconv.Itoa(n-1)
+		}
+	}
+	return sign + s, nil
+}
@@ -0,0 +1,113 @@
+package signature
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatJSONNumber(t *testing.T) {
+	// RFC 8785 Appendix B
+	tests := []struct {
+		bits uint64
+		want string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+	for _, tt := range tests {
+		got, err := FormatJSONNumber(math.Float64frombits(tt.bits))
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got)
+	}
+
+	_, err := FormatJSONNumber(math.NaN())
+	require.ErrorIs(t, err, ErrJCSInvalidNumber)
+	_, err = FormatJSONNumber(math.Inf(1))
+	require.ErrorIs(t, err, ErrJCSInvalidNumber)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"rfc8785 example",
+			`{
+  "numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`,
+			`{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			"utf16 key sort",
+			"{\"\u20ac\":\"Euro Sign\",\"\\r\":\"Carriage Return\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\",\"1\":\"One\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\"}",
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			"nested",
+			`{"b":[{"d":1,"c":"<&>"}],"a":{}}`,
+			`{"a":{},"b":[{"c":"<&>","d":1}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalJSON([]byte(tt.input))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+
+	_, err := CanonicalJSON([]byte(`{"a":1e400}`))
+	require.ErrorIs(t, err, ErrJCSInvalidNumber)
+	_, err = CanonicalJSON([]byte(`{"a":1} x`))
+	require.Error(t, err)
+}
+
+func TestSignJSON(t *testing.T) {
+	secret := "a74db8b7-3b97-4653-8e80-ae90ba0e81b3"
+	raw := json.RawMessage(`{"name":"jjl","amount":10.50,"items":[1,2]}`)
+	obj := map[string]any{
+		"items":  []int{1, 2},
+		"amount": 10.5,
+		"name":   "jjl",
+	}
+
+	s1, err := SignJSON(raw, secret, HexSha256)
+	require.NoError(t, err)
+	s2, err := SignJSON(obj, secret, HexSha256)
+	require.NoError(t, err)
+	require.Equal(t, s1, s2)
+	require.Equal(t, HexSha256(`{"amount":10.5,"items":[1,2],"name":"jjl"}`+secret), s1)
+
+	require.True(t, VerifyJSON(obj, secret, s1, HexSha256))
+	require.False(t, VerifyJSON(obj, "other", s1, HexSha256))
+	require.False(t, VerifyJSON(math.NaN(), secret, s1, HexSha256))
+}
@@ -0,0 +1,252 @@
+package signature
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// keyIDSeparator 输出中密钥 ID 与内容的分隔符
+const keyIDSeparator = ":"
+
+// error defined
+var (
+	ErrKeyNotFound     = errors.New("keyring: key not found")
+	ErrKeyInvalidID    = errors.New("keyring: key id must be not empty and not contain ':'")
+	ErrKeyEmptySecret  = errors.New("keyring: key secret must be not empty")
+	ErrKeyNoActive     = errors.New("keyring: no active key")
+	ErrKeyRemoveActive = errors.New("keyring: can not remove active key")
+	ErrKeyIDMissing    = errors.New("keyring: key id missing in input")
+)
+
+// KeyRing 带 ID 的版本化密钥环.
+// 活动密钥用于签名和加密, 输出带有密钥 ID, 旧密钥保留用于验签和解密, 以支持密钥轮换.
+// 输出格式: <kid>:<content>
+type KeyRing struct {
+	mu     sync.RWMutex
+	keys   map[string]string
+	active string
+}
+
+// NewKeyRing 新建一个空的密钥环
+func NewKeyRing() *KeyRing {
+	return &KeyRing{keys: make(map[string]string)}
+}
+
+// keyRingConfig 密钥环 json 配置
+//
+//	{
+//	  "active": "v2",
+//	  "keys": [
+//	    {"id": "v1", "secret": "..."},
+//	    {"id": "v2", "secret": "..."}
+//	  ]
+//	}
+type keyRingConfig struct {
+	Active string `json:"active"`
+	Keys   []struct {
+		ID     string `json:"id"`
+		Secret string `json:"secret"`
+	} `json:"keys"`
+}
+
+// NewKeyRingFromJSON 从 json 配置中新建密钥环, 格式见 LoadKeyRingFromFile.
+func NewKeyRingFromJSON(data []byte) (*KeyRing, error) {
+	var c keyRingConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("keyring: %w", err)
+	}
+	kr := NewKeyRing()
+	for _, k := range c.Keys {
+		if err := kr.Add(k.ID, k.Secret); err != nil {
+			return nil, err
+		}
+	}
+	if err := kr.SetActive(c.Active); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+// LoadKeyRingFromFile 从 json 文件中加载密钥环.
+// 格式: {"active": "v2", "keys": [{"id": "v1", "secret": "..."}, {"id": "v2", "secret": "..."}]}
+func LoadKeyRingFromFile(filename string) (*KeyRing, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyRingFromJSON(data)
+}
+
+// LoadKeyRingFromEnv 从环境变量中加载密钥环.
+// <prefix>_ACTIVE 为活动密钥 ID, <prefix>_KEY_<ID> 为密钥.
+// 如: APP_KEYRING_ACTIVE=v2, APP_KEYRING_KEY_v1=xxx, APP_KEYRING_KEY_v2=yyy
+func LoadKeyRingFromEnv(prefix string) (*KeyRing, error) {
+	keyPrefix := prefix + "_KEY_"
+	kr := NewKeyRing()
+	for _, env := range os.Environ() {
+		name, value, ok := strings.Cut(env, "=")
+		if !ok || !strings.HasPrefix(name, keyPrefix) {
+			continue
+		}
+		if err := kr.Add(strings.TrimPrefix(name, keyPrefix), value); err != nil {
+			return nil, err
+		}
+	}
+	if err := kr.SetActive(os.Getenv(prefix + "_ACTIVE")); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+// Add 添加密钥, 已存在则覆盖.
+func (kr *KeyRing) Add(id, secret string) error {
+	if id == "" || strings.Contains(id, keyIDSeparator) {
+		return ErrKeyInvalidID
+	}
+	if secret == "" {
+		return ErrKeyEmptySecret
+	}
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	kr.keys[id] = secret
+	return nil
+}
+
+// Remove 移除密钥, 不可移除活动密钥.
+func (kr *KeyRing) Remove(id string) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if id == kr.active {
+		return ErrKeyRemoveActive
+	}
+	delete(kr.keys, id)
+	return nil
+}
+
+// SetActive 设置活动密钥
+func (kr *KeyRing) SetActive(id string) error {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if _, ok := kr.keys[id]; !ok {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, id)
+	}
+	kr.active = id
+	return nil
+}
+
+// Active 获取活动密钥
+func (kr *KeyRing) Active() (id, secret string, err error) {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	if kr.active == "" {
+		return "", "", ErrKeyNoActive
+	}
+	return kr.active, kr.keys[kr.active], nil
+}
+
+// Get 根据 ID 获取密钥
+func (kr *KeyRing) Get(id string) (string, bool) {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	secret, ok := kr.keys[id]
+	return secret, ok
+}
+
+// IDs 所有密钥 ID, 已排序
+func (kr *KeyRing) IDs() []string {
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	ids := make([]string, 0, len(kr.keys))
+	for id := range kr.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Sign 使用活动密钥对象签名, 见 Sign.
+func (kr *KeyRing) Sign(mp map[string]any, sign func(string) string) (string, error) {
+	id, secret, err := kr.Active()
+	if err != nil {
+		return "", err
+	}
+	return joinKeyID(id, Sign(mp, secret, sign)), nil
+}
+
+// VerifySign 根据签名中的密钥 ID 验证对象签名.
+func (kr *KeyRing) VerifySign(mp map[string]any, targetSign string, sign func(string) string) bool {
+	sig, secret, err := kr.splitKeyID(targetSign)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(sig), []byte(Sign(mp, secret, sign))) == 1
+}
+
+// IatSign 使用活动密钥签发获取签发时间和签名.
+func (kr *KeyRing) IatSign(s string) (iat, sign string, err error) {
+	id, secret, err := kr.Active()
+	if err != nil {
+		return "", "", err
+	}
+	iat, sign = IatSignWith(s, func(iat, s string) string {
+		return HmacSha256(secret, iat+s)
+	})
+	return iat, joinKeyID(id, sign), nil
+}
+
+// VerifyIatSign 根据签名中的密钥 ID 验证签发时间是否在有效期内, 并验证签名是否正确.
+func (kr *KeyRing) VerifyIatSign(iat, targetSign, s string, availWindow time.Duration) bool {
+	sig, secret, err := kr.splitKeyID(targetSign)
+	if err != nil {
+		return false
+	}
+	return VerifyIatSignWith(iat, sig, s, availWindow, func(iat, s string) string {
+		return HmacSha256(secret, iat+s)
+	})
+}
+
+// AesCbcEncrypt 使用活动密钥加密, 见 AesCbcEncrypt.
+func (kr *KeyRing) AesCbcEncrypt(rawText []byte) (string, error) {
+	id, secret, err := kr.Active()
+	if err != nil {
+		return "", err
+	}
+	cipherText, err := AesCbcEncrypt(secret, rawText)
+	if err != nil {
+		return "", err
+	}
+	return joinKeyID(id, cipherText), nil
+}
+
+// AesCbcDecrypt 根据密文中的密钥 ID 解密, 见 AesCbcDecrypt.
+func (kr *KeyRing) AesCbcDecrypt(cipherText string) ([]byte, error) {
+	body, secret, err := kr.splitKeyID(cipherText)
+	if err != nil {
+		return nil, err
+	}
+	return AesCbcDecrypt(secret, body)
+}
+
+// splitKeyID 拆分 <kid>:<content>, 并获取对应的密钥
+func (kr *KeyRing) splitKeyID(s string) (content, secret string, err error) {
+	id, content, ok := strings.Cut(s, keyIDSeparator)
+	if !ok || id == "" {
+		return "", "", ErrKeyIDMissing
+	}
+	secret, ok = kr.Get(id)
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", ErrKeyNotFound, id)
+	}
+	return content, secret, nil
+}
+
+func joinKeyID(id, content string) string {
+	return id + keyIDSeparator + content
+}
@@ -0,0 +1,112 @@
+package signature
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyRing(t *testing.T) {
+	kr := NewKeyRing()
+	_, _, err := kr.Active()
+	require.ErrorIs(t, err, ErrKeyNoActive)
+	require.ErrorIs(t, kr.Add("", "secret"), ErrKeyInvalidID)
+	require.ErrorIs(t, kr.Add("v:1", "secret"), ErrKeyInvalidID)
+	require.ErrorIs(t, kr.Add("v1", ""), ErrKeyEmptySecret)
+	require.ErrorIs(t, kr.SetActive("v1"), ErrKeyNotFound)
+
+	require.NoError(t, kr.Add("v1", "1234567890abcdef"))
+	require.NoError(t, kr.SetActive("v1"))
+
+	plainText := []byte("helloworld,this is golang language. welcome")
+	mp := map[string]any{"name": "jjl", "phone": "13705970181"}
+
+	sign1, err := kr.Sign(mp, HexSha256)
+	require.NoError(t, err)
+	iat1, iatSign1, err := kr.IatSign("1888888888")
+	require.NoError(t, err)
+	cipherText1, err := kr.AesCbcEncrypt(plainText)
+	require.NoError(t, err)
+	require.Contains(t, cipherText1, "v1:")
+
+	// 轮换密钥
+	require.NoError(t, kr.Add("v2", "abcdef1234567890abcdef12"))
+	require.NoError(t, kr.SetActive("v2"))
+	require.ErrorIs(t, kr.Remove("v2"), ErrKeyRemoveActive)
+	require.Equal(t, []string{"v1", "v2"}, kr.IDs())
+
+	sign2, err := kr.Sign(mp, HexSha256)
+	require.NoError(t, err)
+	require.NotEqual(t, sign1, sign2)
+	cipherText2, err := kr.AesCbcEncrypt(plainText)
+	require.NoError(t, err)
+	require.Contains(t, cipherText2, "v2:")
+
+	// 旧密钥仍可验证和解密
+	require.True(t, kr.VerifySign(mp, sign1, HexSha256))
+	require.True(t, kr.VerifySign(mp, sign2, HexSha256))
+	require.True(t, kr.VerifyIatSign(iat1, iatSign1, "1888888888", time.Minute))
+	require.False(t, kr.VerifyIatSign(iat1, iatSign1, "1888888889", time.Minute))
+	got, err := kr.AesCbcDecrypt(cipherText1)
+	require.NoError(t, err)
+	require.Equal(t, plainText, got)
+	got, err = kr.AesCbcDecrypt(cipherText2)
+	require.NoError(t, err)
+	require.Equal(t, plainText, got)
+
+	// 移除旧密钥后不可验证
+	require.NoError(t, kr.Remove("v1"))
+	require.False(t, kr.VerifySign(mp, sign1, HexSha256))
+	_, err = kr.AesCbcDecrypt(cipherText1)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	_, err = kr.AesCbcDecrypt("no-key-id")
+	require.ErrorIs(t, err, ErrKeyIDMissing)
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "keyring.json")
+		err := os.WriteFile(filename, []byte(`{
+  "active": "v2",
+  "keys": [
+    {"id": "v1", "secret": "1234567890abcdef"},
+    {"id": "v2", "secret": "abcdef1234567890"}
+  ]
+}`), 0o600)
+		require.NoError(t, err)
+
+		kr, err := LoadKeyRingFromFile(filename)
+		require.NoError(t, err)
+		id, secret, err := kr.Active()
+		require.NoError(t, err)
+		require.Equal(t, "v2", id)
+		require.Equal(t, "abcdef1234567890", secret)
+		require.Equal(t, []string{"v1", "v2"}, kr.IDs())
+
+		_, err = NewKeyRingFromJSON([]byte(`{"active": "v3", "keys": [{"id": "v1", "secret": "x"}]}`))
+		require.ErrorIs(t, err, ErrKeyNotFound)
+		_, err = NewKeyRingFromJSON([]byte(`{`))
+		require.Error(t, err)
+		_, err = LoadKeyRingFromFile(filepath.Join(t.TempDir(), "not_exist.json"))
+		require.Error(t, err)
+	})
+	t.Run("env", func(t *testing.T) {
+		t.Setenv("CLIP_TEST_KEYRING_ACTIVE", "v1")
+		t.Setenv("CLIP_TEST_KEYRING_KEY_v1", "1234567890abcdef")
+		t.Setenv("CLIP_TEST_KEYRING_KEY_v2", "abcdef1234567890")
+
+		kr, err := LoadKeyRingFromEnv("CLIP_TEST_KEYRING")
+		require.NoError(t, err)
+		id, secret, err := kr.Active()
+		require.NoError(t, err)
+		require.Equal(t, "v1", id)
+		require.Equal(t, "1234567890abcdef", secret)
+		require.Equal(t, []string{"v1", "v2"}, kr.IDs())
+
+		_, err = LoadKeyRingFromEnv("CLIP_TEST_KEYRING_NOT_EXIST")
+		require.ErrorIs(t, err, ErrKeyNotFound)
+	})
+}
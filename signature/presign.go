@@ -0,0 +1,231 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// presigned url query parameters
+const (
+	PresignExpires       = "X-Expires"
+	PresignKeyID         = "X-Key-Id"
+	PresignSignedHeaders = "X-Signed-Headers"
+	PresignBindIP        = "X-Bind-Ip"
+	PresignSignature     = "X-Signature"
+)
+
+// error defined
+var (
+	ErrPresignMissing   = errors.New("presign: missing presigned parameters")
+	ErrPresignExpired   = errors.New("presign: url expired")
+	ErrPresignSignature = fmt.Errorf("presign: %w", ErrSignatureMismatch)
+)
+
+// PresignerOption presigner option
+type PresignerOption func(*Presigner)
+
+// WithPresignTimeFunc 当前时间函数, 默认 time.Now
+func WithPresignTimeFunc(f func() time.Time) PresignerOption {
+	return func(p *Presigner) {
+		p.timeFunc = f
+	}
+}
+
+// WithPresignClientIP 验证时获取客户端 ip 的函数, 默认取 RemoteAddr.
+// 在代理后面时, 可自定义从 X-Forwarded-For 等头部获取.
+func WithPresignClientIP(f func(*http.Request) string) PresignerOption {
+	return func(p *Presigner) {
+		p.clientIP = f
+	}
+}
+
+// Presigner 预签名 url 的生成和验证.
+// 签名内容为 method + path + 排序后的 query, 使用 KeyRing 的活动密钥签名, 并带上密钥 ID 和过期时间.
+type Presigner struct {
+	keyRing  *KeyRing
+	timeFunc func() time.Time
+	clientIP func(*http.Request) string
+}
+
+// NewPresigner 新建预签名器
+func NewPresigner(kr *KeyRing, opts ...PresignerOption) *Presigner {
+	p := &Presigner{
+		keyRing:  kr,
+		timeFunc: time.Now,
+		clientIP: remoteIP,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+type presignOptions struct {
+	clientIP string
+	header   http.Header
+	names    []string
+}
+
+// PresignOption presign option
+type PresignOption func(*presignOptions)
+
+// BindClientIP 绑定客户端 ip, 仅该 ip 可以访问.
+func BindClientIP(ip string) PresignOption {
+	return func(o *presignOptions) {
+		o.clientIP = ip
+	}
+}
+
+// BindHeaders 绑定请求头, 访问时必须带有相同的请求头.
+func BindHeaders(header http.Header, names ...string) PresignOption {
+	return func(o *presignOptions) {
+		o.header = header
+		o.names = names
+	}
+}
+
+// Presign 生成有效期为 expires 的预签名 url.
+func (p *Presigner) Presign(method, rawURL string, expires time.Duration, opts ...PresignOption) (string, error) {
+	o := &presignOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	id, secret, err := p.keyRing.Active()
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Del(PresignSignature)
+	query.Set(PresignExpires, strconv.FormatInt(p.timeFunc().Add(expires).Unix(), 10))
+	query.Set(PresignKeyID, id)
+	if o.clientIP != "" {
+		query.Set(PresignBindIP, "1")
+	}
+	names := make([]string, 0, len(o.names))
+	for _, name := range o.names {
+		names = append(names, strings.ToLower(name))
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		query.Set(PresignSignedHeaders, strings.Join(names, ";"))
+	}
+	query.Set(PresignSignature, presignSignature(secret, method, u.Path, query, o.clientIP, o.header))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Verify 验证请求的预签名 url
+func (p *Presigner) Verify(r *http.Request) error {
+	query := r.URL.Query()
+	expires, id, sig := query.Get(PresignExpires), query.Get(PresignKeyID), query.Get(PresignSignature)
+	if expires == "" || id == "" || sig == "" {
+		return ErrPresignMissing
+	}
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrPresignMissing
+	}
+	if p.timeFunc().Unix() > exp {
+		return ErrPresignExpired
+	}
+	secret, ok := p.keyRing.Get(id)
+	if !ok {
+		return ErrKeyNotFound
+	}
+	clientIP := ""
+	if query.Get(PresignBindIP) != "" {
+		clientIP = p.clientIP(r)
+	}
+	want := presignSignature(secret, r.Method, r.URL.Path, query, clientIP, r.Header)
+	if !hmac.Equal([]byte(sig), []byte(want)) {
+		return ErrPresignSignature
+	}
+	return nil
+}
+
+// Middleware 验证预签名 url 的 http 中间件.
+// errHandler 验证失败时调用, 为 nil 时返回 403.
+func (p *Presigner) Middleware(errHandler func(http.ResponseWriter, *http.Request, error)) func(http.Handler) http.Handler {
+	if errHandler == nil {
+		errHandler = func(w http.ResponseWriter, _ *http.Request, _ error) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := p.Verify(r); err != nil {
+				errHandler(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// presignSignature 签名内容:
+//
+//	METHOD\n
+//	path\n
+//	k1=v1&k2=v2 (除签名外排序后的 query)\n
+//	client ip\n
+//	name1:value1\nname2:value2 (绑定的请求头)
+func presignSignature(secret, method, path string, query url.Values, clientIP string, header http.Header) string {
+	b := &strings.Builder{}
+	b.WriteString(strings.ToUpper(method))
+	b.WriteString("\n")
+	b.WriteString(path)
+	b.WriteString("\n")
+	b.WriteString(canonicalQuery(query))
+	b.WriteString("\n")
+	b.WriteString(clientIP)
+	if names := query.Get(PresignSignedHeaders); names != "" {
+		for _, name := range strings.Split(names, ";") {
+			b.WriteString("\n")
+			b.WriteString(name)
+			b.WriteString(":")
+			b.WriteString(strings.Join(header.Values(name), ","))
+		}
+	}
+	return EncodingBase64URL.EncodeToString(Hmac(sha256.New, []byte(secret), []byte(b.String())))
+}
+
+// canonicalQuery 除签名外按 key, value 排序后的 query, 值为空的参数同样参与签名.
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for k, vs := range query {
+		if k == PresignSignature {
+			continue
+		}
+		ek := url.QueryEscape(k)
+		if len(vs) == 0 {
+			pairs = append(pairs, ek+"=")
+		}
+		for _, v := range vs {
+			pairs = append(pairs, ek+"="+url.QueryEscape(v))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
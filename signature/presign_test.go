@@ -0,0 +1,123 @@
+package signature
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyRing(t *testing.T) *KeyRing {
+	kr := NewKeyRing()
+	require.NoError(t, kr.Add("v1", "1234567890abcdef"))
+	require.NoError(t, kr.SetActive("v1"))
+	return kr
+}
+
+func TestPresigner(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	p := NewPresigner(newTestKeyRing(t), WithPresignTimeFunc(func() time.Time { return now }))
+
+	rawURL, err := p.Presign(http.MethodGet, "https://example.com/files/a b.pdf?b=2&a=1", time.Hour)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, rawURL, nil)
+	require.NoError(t, p.Verify(r))
+
+	t.Run("method mismatch", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodDelete, rawURL, nil)
+		require.ErrorIs(t, p.Verify(r), ErrPresignSignature)
+		require.ErrorIs(t, p.Verify(r), ErrSignatureMismatch)
+	})
+	t.Run("query tampered", func(t *testing.T) {
+		u, _ := url.Parse(rawURL)
+		q := u.Query()
+		q.Set("a", "100")
+		u.RawQuery = q.Encode()
+		require.ErrorIs(t, p.Verify(httptest.NewRequest(http.MethodGet, u.String(), nil)), ErrPresignSignature)
+	})
+	t.Run("empty value appended", func(t *testing.T) {
+		for _, extra := range []string{"&admin=", "&admin", "&a="} {
+			r := httptest.NewRequest(http.MethodGet, rawURL+extra, nil)
+			require.ErrorIs(t, p.Verify(r), ErrPresignSignature, extra)
+		}
+	})
+	t.Run("empty value signed", func(t *testing.T) {
+		rawURL, err := p.Presign(http.MethodGet, "https://example.com/files/a.pdf?download=", time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, p.Verify(httptest.NewRequest(http.MethodGet, rawURL, nil)))
+
+		u, _ := url.Parse(rawURL)
+		q := u.Query()
+		q.Del("download")
+		u.RawQuery = q.Encode()
+		require.ErrorIs(t, p.Verify(httptest.NewRequest(http.MethodGet, u.String(), nil)), ErrPresignSignature)
+	})
+	t.Run("path tampered", func(t *testing.T) {
+		u, _ := url.Parse(rawURL)
+		u.Path = "/files/b.pdf"
+		require.ErrorIs(t, p.Verify(httptest.NewRequest(http.MethodGet, u.String(), nil)), ErrPresignSignature)
+	})
+	t.Run("expired", func(t *testing.T) {
+		later := NewPresigner(p.keyRing, WithPresignTimeFunc(func() time.Time { return now.Add(2 * time.Hour) }))
+		require.ErrorIs(t, later.Verify(r), ErrPresignExpired)
+	})
+	t.Run("missing", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "https://example.com/files/a.pdf", nil)
+		require.ErrorIs(t, p.Verify(r), ErrPresignMissing)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		kr := NewKeyRing()
+		require.NoError(t, kr.Add("v2", "abcdef1234567890"))
+		require.NoError(t, kr.SetActive("v2"))
+		require.ErrorIs(t, NewPresigner(kr, WithPresignTimeFunc(p.timeFunc)).Verify(r), ErrKeyNotFound)
+	})
+}
+
+func TestPresignerBind(t *testing.T) {
+	p := NewPresigner(newTestKeyRing(t))
+
+	t.Run("client ip", func(t *testing.T) {
+		rawURL, err := p.Presign(http.MethodGet, "https://example.com/download", time.Minute, BindClientIP("10.0.0.1"))
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodGet, rawURL, nil)
+		r.RemoteAddr = "10.0.0.1:5678"
+		require.NoError(t, p.Verify(r))
+		r.RemoteAddr = "10.0.0.2:5678"
+		require.ErrorIs(t, p.Verify(r), ErrPresignSignature)
+	})
+	t.Run("headers", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-Tenant", "t1")
+		rawURL, err := p.Presign(http.MethodPut, "https://example.com/upload", time.Minute, BindHeaders(header, "X-Tenant"))
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPut, rawURL, nil)
+		r.Header.Set("X-Tenant", "t1")
+		require.NoError(t, p.Verify(r))
+		r.Header.Set("X-Tenant", "t2")
+		require.ErrorIs(t, p.Verify(r), ErrPresignSignature)
+	})
+}
+
+func TestPresignerMiddleware(t *testing.T) {
+	p := NewPresigner(newTestKeyRing(t))
+	handler := p.Middleware(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rawURL, err := p.Presign(http.MethodGet, "https://example.com/download", time.Minute)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, rawURL, nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://example.com/download", nil))
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
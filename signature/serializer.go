@@ -0,0 +1,143 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+// error defined
+var (
+	ErrBadSignature     = fmt.Errorf("serializer: %w", ErrSignatureMismatch)
+	ErrBadPayload       = errors.New("serializer: payload is malformed")
+	ErrSignatureExpired = errors.New("serializer: signature expired")
+)
+
+// ExpiredError 签名已过期, errors.Is(err, ErrSignatureExpired) 为 true.
+type ExpiredError struct {
+	// IssuedAt 签发时间
+	IssuedAt time.Time
+	// MaxAge 有效期
+	MaxAge time.Duration
+	// Now 校验时的当前时间, 由 WithSerializerTimeFunc 提供
+	Now time.Time
+}
+
+func (e *ExpiredError) Error() string {
+	return fmt.Sprintf("serializer: signature age %s > %s", e.Now.Sub(e.IssuedAt).Truncate(time.Second), e.MaxAge)
+}
+
+// Is 使得 errors.Is(err, ErrSignatureExpired) 成立
+func (e *ExpiredError) Is(target error) bool { return target == ErrSignatureExpired }
+
+// SerializerOption serializer option
+type SerializerOption func(*Serializer)
+
+// WithSerializerSalt 盐/命名空间, 不同用途(如邮箱验证, 下载链接)使用不同的盐, 使得 token 不能互用.
+func WithSerializerSalt(salt string) SerializerOption {
+	return func(s *Serializer) {
+		s.salt = salt
+	}
+}
+
+// WithSerializerHash hmac 的哈希函数, 默认 sha256.New
+func WithSerializerHash(h func() hash.Hash) SerializerOption {
+	return func(s *Serializer) {
+		s.hash = h
+	}
+}
+
+// WithSerializerTimeFunc 当前时间函数, 默认 time.Now
+func WithSerializerTimeFunc(f func() time.Time) SerializerOption {
+	return func(s *Serializer) {
+		s.timeFunc = f
+	}
+}
+
+// Serializer 带时间戳的签名序列化器, 类似 python itsdangerous 的 URLSafeTimedSerializer.
+// 格式: base64url(json(payload)).base64url(timestamp).base64url(hmac), 可直接用于 url 中.
+type Serializer struct {
+	secret   string
+	salt     string
+	hash     func() hash.Hash
+	timeFunc func() time.Time
+}
+
+// NewSerializer 新建序列化器
+func NewSerializer(secret string, opts ...SerializerOption) *Serializer {
+	s := &Serializer{
+		secret:   secret,
+		salt:     "clip.signature.Serializer",
+		hash:     sha256.New,
+		timeFunc: time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Dumps 序列化并签名 v
+func (s *Serializer) Dumps(v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(s.timeFunc().Unix()))
+
+	value := base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(ts)
+	return value + "." + base64.RawURLEncoding.EncodeToString(s.signature(value)), nil
+}
+
+// Loads 校验签名并反序列化到 v.
+// maxAge > 0 时校验有效期, 过期返回 *ExpiredError(ErrSignatureExpired),
+// 签名错误返回 ErrBadSignature, 格式错误返回 ErrBadPayload.
+func (s *Serializer) Loads(token string, v any, maxAge time.Duration) error {
+	idx := strings.LastIndexByte(token, '.')
+	if idx < 0 {
+		return ErrBadSignature
+	}
+	value, sig := token[:idx], token[idx+1:]
+	rawSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(rawSig, s.signature(value)) {
+		return ErrBadSignature
+	}
+
+	payload, ts, ok := strings.Cut(value, ".")
+	if !ok {
+		return ErrBadPayload
+	}
+	rawTs, err := base64.RawURLEncoding.DecodeString(ts)
+	if err != nil || len(rawTs) != 8 {
+		return ErrBadPayload
+	}
+	if maxAge > 0 {
+		issuedAt := time.Unix(int64(binary.BigEndian.Uint64(rawTs)), 0)
+		now := s.timeFunc()
+		if now.Sub(issuedAt) > maxAge {
+			return &ExpiredError{IssuedAt: issuedAt, MaxAge: maxAge, Now: now}
+		}
+	}
+	rawPayload, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return ErrBadPayload
+	}
+	if err = json.Unmarshal(rawPayload, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrBadPayload, err)
+	}
+	return nil
+}
+
+func (s *Serializer) signature(value string) []byte {
+	// 由 secret 和 salt 派生签名密钥
+	key := Hmac(s.hash, []byte(s.secret), []byte(s.salt))
+	return Hmac(s.hash, key, []byte(value))
+}
@@ -0,0 +1,60 @@
+package signature
+
+import (
+	"crypto/sha512"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerializer(t *testing.T) {
+	type payload struct {
+		UserID int64  `json:"user_id"`
+		Email  string `json:"email"`
+	}
+	now := time.Unix(1700000000, 0)
+	want := payload{UserID: 10086, Email: "jjl@example.com"}
+
+	s := NewSerializer("secret", WithSerializerSalt("email-verify"), WithSerializerTimeFunc(func() time.Time { return now }))
+	token, err := s.Dumps(want)
+	require.NoError(t, err)
+	require.Equal(t, url.QueryEscape(token), token)
+
+	var got payload
+	require.NoError(t, s.Loads(token, &got, time.Hour))
+	require.Equal(t, want, got)
+
+	t.Run("expired", func(t *testing.T) {
+		later := NewSerializer("secret", WithSerializerSalt("email-verify"), WithSerializerTimeFunc(func() time.Time { return now.Add(2 * time.Hour) }))
+		err := later.Loads(token, &got, time.Hour)
+		require.ErrorIs(t, err, ErrSignatureExpired)
+		var e *ExpiredError
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, now, e.IssuedAt)
+		require.Equal(t, now.Add(2*time.Hour), e.Now)
+		require.Equal(t, "serializer: signature age 2h0m0s > 1h0m0s", err.Error())
+		// 不校验有效期
+		require.NoError(t, later.Loads(token, &got, 0))
+	})
+	t.Run("bad signature", func(t *testing.T) {
+		for _, other := range []*Serializer{
+			NewSerializer("other"),
+			NewSerializer("secret", WithSerializerSalt("download")),
+			NewSerializer("secret", WithSerializerSalt("email-verify"), WithSerializerHash(sha512.New)),
+		} {
+			require.ErrorIs(t, other.Loads(token, &got, 0), ErrBadSignature)
+		}
+		require.ErrorIs(t, s.Loads(token+"x", &got, 0), ErrBadSignature)
+		require.ErrorIs(t, s.Loads(token+"x", &got, 0), ErrSignatureMismatch)
+		require.ErrorIs(t, s.Loads("x"+token, &got, 0), ErrBadSignature)
+		require.ErrorIs(t, s.Loads("nodot", &got, 0), ErrBadSignature)
+	})
+	t.Run("bad payload", func(t *testing.T) {
+		value := "bm90IGpzb24.AAAAAGVT8QA"
+		token := value + "." + EncodingBase64URL.EncodeToString(s.signature(value))
+		require.ErrorIs(t, s.Loads(token, &got, 0), ErrBadPayload)
+	})
+}
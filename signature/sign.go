@@ -2,10 +2,26 @@ package signature
 
 import (
 	"crypto/subtle"
+	"errors"
+	"fmt"
 	"strconv"
+	"sync"
 	"time"
 )
 
+// error defined
+var (
+	ErrIatMalformed      = errors.New("signature: iat is malformed")
+	ErrIatExpired        = errors.New("signature: iat is expired")
+	ErrIatInFuture       = errors.New("signature: iat is in the future")
+	ErrSignatureMismatch = errors.New("signature: signature mismatch")
+	ErrNonceReplayed     = errors.New("signature: nonce replayed")
+	ErrNonceEmpty        = errors.New("signature: nonce is empty")
+)
+
+// memoryNonceSweepEvery MemoryNonceStore 每添加多少次清理一次已过期的随机数
+const memoryNonceSweepEvery = 1024
+
 // IatSign 签发获取签发时间和签名
 func IatSign(s string) (iat, sign string) {
 	return IatSignWith(s, func(iat, s string) string {
@@ -13,9 +29,17 @@ func IatSign(s string) (iat, sign string) {
 	})
 }
 
-// VerifyIatSign 验证签发时间是否在有效期内, 并验证签名是否正确
+// VerifyIatSign 验证签发时间是否在有效期内, 并验证签名是否正确.
+// 与 VerifyIat 相同, 不拒绝未来的签发时间, 需要时使用 ValidateIatSign.
 func VerifyIatSign(iat, targetSign, s string, iatTimout time.Duration) bool {
-	return VerifyIatSignWith(iat, targetSign, s, iatTimout, func(iat, s string) string {
+	return validateIatSignWith(iat, targetSign, s, iatTimout, func(iat, s string) string {
+		return HmacSha256(iat, iat+s)
+	}, false) == nil
+}
+
+// ValidateIatSign 验证签发时间是否在有效期内, 并验证签名是否正确, 失败返回具体的错误.
+func ValidateIatSign(iat, targetSign, s string, availWindow time.Duration) error {
+	return ValidateIatSignWith(iat, targetSign, s, availWindow, func(iat, s string) string {
 		return HmacSha256(iat, iat+s)
 	})
 }
@@ -26,10 +50,26 @@ func IatSignWith(s string, hash func(iat, s string) string) (iat, sign string) {
 	return iat, hash(iat, s)
 }
 
-// VerifyIatSignWith 验证签发时间是否在有效期内, 并验证签名是否正确
+// VerifyIatSignWith 验证签发时间是否在有效期内, 并验证签名是否正确.
+// 与 VerifyIat 相同, 不拒绝未来的签发时间, 需要时使用 ValidateIatSignWith.
 func VerifyIatSignWith(iat, targetSign, s string, availWindow time.Duration, hash func(iat, s string) string) bool {
-	return VerifyIat(iat, availWindow) &&
-		subtle.ConstantTimeCompare([]byte(targetSign), []byte(hash(iat, s))) == 1
+	return validateIatSignWith(iat, targetSign, s, availWindow, hash, false) == nil
+}
+
+// ValidateIatSignWith 验证签发时间是否在有效期内, 并验证签名是否正确, 失败返回具体的错误:
+// ErrIatMalformed, ErrIatExpired, ErrIatInFuture, ErrSignatureMismatch.
+func ValidateIatSignWith(iat, targetSign, s string, availWindow time.Duration, hash func(iat, s string) string) error {
+	return validateIatSignWith(iat, targetSign, s, availWindow, hash, true)
+}
+
+func validateIatSignWith(iat, targetSign, s string, availWindow time.Duration, hash func(iat, s string) string, rejectFuture bool) error {
+	if err := validateIat(iat, availWindow, rejectFuture); err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(targetSign), []byte(hash(iat, s))) != 1 {
+		return ErrSignatureMismatch
+	}
+	return nil
 }
 
 // Iat 签发时间字符串
@@ -37,12 +77,89 @@ func Iat() string {
 	return strconv.FormatInt(time.Now().UnixNano(), 10)
 }
 
-// VerifyIat 验证签发时间是否在有效期内
+// VerifyIat 验证签发时间是否在有效期内.
+// 为保持兼容, 不拒绝未来的签发时间, 需要拒绝时钟异常的请求时使用 ValidateIat.
 func VerifyIat(iat string, availWindow time.Duration) bool {
+	return validateIat(iat, availWindow, false) == nil
+}
+
+// ValidateIat 验证签发时间是否在有效期内, 失败返回具体的错误.
+// 允许双方时钟相差 availWindow, 签发时间超过当前时间 availWindow 以上, 视为时钟异常, 返回 ErrIatInFuture.
+func ValidateIat(iat string, availWindow time.Duration) error {
+	return validateIat(iat, availWindow, true)
+}
+
+func validateIat(iat string, availWindow time.Duration, rejectFuture bool) error {
 	ns, err := strconv.ParseInt(iat, 10, 64)
 	if err != nil {
-		return false
+		return fmt.Errorf("%w: %v", ErrIatMalformed, err)
 	}
 	t := time.Unix(ns/int64(time.Second), ns%int64(time.Second))
-	return t.Add(availWindow).After(time.Now())
+	now := time.Now()
+	if !t.Add(availWindow).After(now) {
+		return ErrIatExpired
+	}
+	if rejectFuture && t.After(now.Add(availWindow)) {
+		return ErrIatInFuture
+	}
+	return nil
+}
+
+// NonceStore 随机数存储, 用于防重放
+type NonceStore interface {
+	// Add 添加随机数, ttl 后过期, 已存在返回 false.
+	Add(nonce string, ttl time.Duration) bool
+}
+
+// MemoryNonceStore 内存随机数存储, 仅适用于单实例.
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	adds   int
+	now    func() time.Time
+}
+
+// NewMemoryNonceStore 新建内存随机数存储
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{
+		nonces: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Add implement NonceStore, 每添加 memoryNonceSweepEvery 次清理一次已过期的随机数.
+func (m *MemoryNonceStore) Add(nonce string, ttl time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.now()
+	m.adds++
+	if m.adds%memoryNonceSweepEvery == 0 {
+		m.sweep(now)
+	}
+	if expireAt, ok := m.nonces[nonce]; ok && expireAt.After(now) {
+		return false
+	}
+	m.nonces[nonce] = now.Add(ttl)
+	return true
+}
+
+// sweep 清理已过期的随机数
+func (m *MemoryNonceStore) sweep(now time.Time) {
+	for k, expireAt := range m.nonces {
+		if !expireAt.After(now) {
+			delete(m.nonces, k)
+		}
+	}
+}
+
+// ValidateNonce 验证随机数是否已使用过, 为空返回 ErrNonceEmpty, 已使用返回 ErrNonceReplayed.
+// ttl 一般不小于签发时间的有效期.
+func ValidateNonce(store NonceStore, nonce string, ttl time.Duration) error {
+	if nonce == "" {
+		return ErrNonceEmpty
+	}
+	if !store.Add(nonce, ttl) {
+		return ErrNonceReplayed
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package signature
 
 import (
 	"encoding/json"
+	"strconv"
 	"testing"
 	"time"
 
@@ -78,3 +79,53 @@ func TestIatSign(t *testing.T) {
 	time.Sleep(time.Second)
 	require.False(t, VerifyIatSign(iat, sign, str, time.Millisecond*500))
 }
+
+func TestValidateIat(t *testing.T) {
+	require.NoError(t, ValidateIat(Iat(), time.Second))
+	require.ErrorIs(t, ValidateIat("abc", time.Second), ErrIatMalformed)
+	require.ErrorIs(t, ValidateIat(strconv.FormatInt(time.Now().Add(-time.Minute).UnixNano(), 10), time.Second), ErrIatExpired)
+	require.ErrorIs(t, ValidateIat(strconv.FormatInt(time.Now().Add(time.Minute).UnixNano(), 10), time.Second), ErrIatInFuture)
+	// VerifyIat 不拒绝未来的签发时间
+	require.True(t, VerifyIat(strconv.FormatInt(time.Now().Add(time.Minute).UnixNano(), 10), time.Second))
+}
+
+func TestValidateIatSign(t *testing.T) {
+	str := "1888888888"
+	iat, sign := IatSign(str)
+	require.NoError(t, ValidateIatSign(iat, sign, str, time.Second))
+	require.ErrorIs(t, ValidateIatSign(iat, "sign", str, time.Second), ErrSignatureMismatch)
+	require.ErrorIs(t, ValidateIatSign("x"+iat, sign, str, time.Second), ErrIatMalformed)
+
+	expired := strconv.FormatInt(time.Now().Add(-time.Minute).UnixNano(), 10)
+	require.ErrorIs(t, ValidateIatSign(expired, sign, str, time.Second), ErrIatExpired)
+
+	future := strconv.FormatInt(time.Now().Add(time.Minute).UnixNano(), 10)
+	futureSign := HmacSha256(future, future+str)
+	require.ErrorIs(t, ValidateIatSign(future, futureSign, str, time.Second), ErrIatInFuture)
+	require.True(t, VerifyIatSign(future, futureSign, str, time.Second))
+	require.False(t, VerifyIatSign(future, sign, str, time.Second))
+}
+
+func TestValidateNonce(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryNonceStore()
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, ValidateNonce(store, "n1", time.Minute))
+	require.ErrorIs(t, ValidateNonce(store, "n1", time.Minute), ErrNonceReplayed)
+	require.NoError(t, ValidateNonce(store, "n2", time.Minute))
+	require.ErrorIs(t, ValidateNonce(store, "", time.Minute), ErrNonceEmpty)
+
+	// 过期后可以再次使用
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, ValidateNonce(store, "n1", time.Minute))
+
+	// 每添加 memoryNonceSweepEvery 次清理一次
+	for i := store.adds; i < memoryNonceSweepEvery-1; i++ {
+		require.True(t, store.Add(strconv.Itoa(i), time.Minute))
+	}
+	require.Greater(t, len(store.nonces), memoryNonceSweepEvery/2)
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, ValidateNonce(store, "n3", time.Minute))
+	require.Len(t, store.nonces, 1)
+}
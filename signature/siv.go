@@ -0,0 +1,165 @@
+package signature
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+)
+
+// error defined
+var (
+	ErrSivInvalidKeySize = errors.New("siv: key length must be one of 32, 48, 64")
+	ErrSivAuthFailed     = errors.New("siv: message authentication failed")
+)
+
+// AesSivEncrypt aes siv (RFC 5297) 确定性加密, v + ciphertext with base64 encoded.
+// 相同的 key, 明文和 ad 得到相同的密文, 可用于加密字段的等值查询.
+// key must one of 32, 48, 64, 前一半用于 S2V, 后一半用于 CTR.
+func AesSivEncrypt(key string, rawText []byte, ad ...[]byte) (string, error) {
+	out, err := sivSeal([]byte(key), rawText, ad)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+// AesSivDecrypt aes siv (RFC 5297), base64 decoded v + ciphertext.
+// key must one of 32, 48, 64
+func AesSivDecrypt(key, cipherText string, ad ...[]byte) ([]byte, error) {
+	body, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return nil, err
+	}
+	return sivOpen([]byte(key), body, ad)
+}
+
+func sivSeal(key, plaintext []byte, ad [][]byte) ([]byte, error) {
+	macBlock, ctrBlock, err := sivCiphers(key)
+	if err != nil {
+		return nil, err
+	}
+	v := s2v(macBlock, plaintext, ad)
+	out := make([]byte, aes.BlockSize+len(plaintext))
+	copy(out, v)
+	sivCtr(ctrBlock, v, out[aes.BlockSize:], plaintext)
+	return out, nil
+}
+
+func sivOpen(key, cipherText []byte, ad [][]byte) ([]byte, error) {
+	macBlock, ctrBlock, err := sivCiphers(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(cipherText) < aes.BlockSize {
+		return nil, ErrSivAuthFailed
+	}
+	v, body := cipherText[:aes.BlockSize], cipherText[aes.BlockSize:]
+	plaintext := make([]byte, len(body))
+	sivCtr(ctrBlock, v, plaintext, body)
+	if subtle.ConstantTimeCompare(v, s2v(macBlock, plaintext, ad)) != 1 {
+		return nil, ErrSivAuthFailed
+	}
+	return plaintext, nil
+}
+
+func sivCiphers(key []byte) (macBlock, ctrBlock cipher.Block, err error) {
+	if !(len(key) == 32 || len(key) == 48 || len(key) == 64) {
+		return nil, nil, ErrSivInvalidKeySize
+	}
+	half := len(key) / 2
+	if macBlock, err = aes.NewCipher(key[:half]); err != nil {
+		return nil, nil, err
+	}
+	if ctrBlock, err = aes.NewCipher(key[half:]); err != nil {
+		return nil, nil, err
+	}
+	return macBlock, ctrBlock, nil
+}
+
+// sivCtr CTR 模式, 计数器初始值为 v 清除第 63 和 31 位.
+func sivCtr(block cipher.Block, v, dst, src []byte) {
+	iv := make([]byte, aes.BlockSize)
+	copy(iv, v)
+	iv[8] &= 0x7f
+	iv[12] &= 0x7f
+	cipher.NewCTR(block, iv).XORKeyStream(dst, src)
+}
+
+// s2v RFC 5297 2.4
+func s2v(block cipher.Block, plaintext []byte, ad [][]byte) []byte {
+	d := cmac(block, make([]byte, aes.BlockSize))
+	for _, s := range ad {
+		d = dbl(d)
+		xorBytes(d, cmac(block, s))
+	}
+	var t []byte
+	if len(plaintext) >= aes.BlockSize {
+		t = make([]byte, len(plaintext))
+		copy(t, plaintext)
+		xorBytes(t[len(t)-aes.BlockSize:], d)
+	} else {
+		t = dbl(d)
+		xorBytes(t, pad(plaintext))
+	}
+	return cmac(block, t)
+}
+
+// cmac RFC 4493
+func cmac(block cipher.Block, msg []byte) []byte {
+	l := make([]byte, aes.BlockSize)
+	block.Encrypt(l, l)
+	k1 := dbl(l)
+
+	n := (len(msg) + aes.BlockSize - 1) / aes.BlockSize
+	var last []byte
+	if n == 0 || len(msg)%aes.BlockSize != 0 {
+		if n == 0 {
+			n = 1
+		}
+		last = pad(msg[(n-1)*aes.BlockSize:])
+		xorBytes(last, dbl(k1))
+	} else {
+		last = make([]byte, aes.BlockSize)
+		copy(last, msg[(n-1)*aes.BlockSize:])
+		xorBytes(last, k1)
+	}
+
+	x := make([]byte, aes.BlockSize)
+	for i := 0; i < n-1; i++ {
+		xorBytes(x, msg[i*aes.BlockSize:(i+1)*aes.BlockSize])
+		block.Encrypt(x, x)
+	}
+	xorBytes(x, last)
+	block.Encrypt(x, x)
+	return x
+}
+
+// dbl 在 GF(2^128) 上乘以 x
+func dbl(b []byte) []byte {
+	out := make([]byte, len(b))
+	var carry byte
+	for i := len(b) - 1; i >= 0; i-- {
+		out[i] = b[i]<<1 | carry
+		carry = b[i] >> 7
+	}
+	if carry != 0 {
+		out[len(out)-1] ^= 0x87
+	}
+	return out
+}
+
+// pad 10* 填充到一个块
+func pad(b []byte) []byte {
+	out := make([]byte, aes.BlockSize)
+	copy(out, b)
+	out[len(b)] = 0x80
+	return out
+}
+
+func xorBytes(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
@@ -0,0 +1,82 @@
+package signature
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestAesSiv(t *testing.T) {
+	// RFC 5297 Appendix A
+	tests := []struct {
+		name      string
+		key       []byte
+		ad        [][]byte
+		plaintext []byte
+		want      []byte
+	}{
+		{
+			"A.1 deterministic",
+			mustHex("fffefdfc fbfaf9f8 f7f6f5f4 f3f2f1f0 f0f1f2f3 f4f5f6f7 f8f9fafb fcfdfeff"),
+			[][]byte{mustHex("10111213 14151617 18191a1b 1c1d1e1f 20212223 24252627")},
+			mustHex("11223344 55667788 99aabbcc ddee"),
+			mustHex("85632d07 c6e8f37f 950acd32 0a2ecc93 40c02b96 90c4dc04 daef7f6a fe5c"),
+		},
+		{
+			"A.2 nonce-based",
+			mustHex("7f7e7d7c 7b7a7978 77767574 73727170 40414243 44454647 48494a4b 4c4d4e4f"),
+			[][]byte{
+				mustHex("00112233 44556677 8899aabb ccddeeff deaddada deaddada ffeeddcc bbaa9988 77665544 33221100"),
+				mustHex("10203040 50607080 90a0"),
+				mustHex("09f91102 9d74e35b d84156c5 635688c0"),
+			},
+			mustHex("74686973 20697320 736f6d65 20706c61 696e7465 78742074 6f20656e 63727970 74207573 696e6720 5349562d 414553"),
+			mustHex("7bdb6e3b 432667eb 06f4d14b ff2fbd0f cb900f2f ddbe4043 26601965 c889bf17 dba77ceb 094fa663 b7a3f748 ba8af829 ea64ad54 4a272e9c 485b62a3 fd5c0d"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AesSivEncrypt(string(tt.key), tt.plaintext, tt.ad...)
+			require.NoError(t, err)
+			require.Equal(t, base64.StdEncoding.EncodeToString(tt.want), got)
+
+			plaintext, err := AesSivDecrypt(string(tt.key), got, tt.ad...)
+			require.NoError(t, err)
+			require.Equal(t, tt.plaintext, plaintext)
+
+			_, err = AesSivDecrypt(string(tt.key), got)
+			require.ErrorIs(t, err, ErrSivAuthFailed)
+		})
+	}
+}
+
+func TestAesSivDeterministic(t *testing.T) {
+	key := strings.Repeat("k", 64)
+	for _, plainText := range []string{"", "a", "jjl@example.com", strings.Repeat("x", 100)} {
+		c1, err := AesSivEncrypt(key, []byte(plainText))
+		require.NoError(t, err)
+		c2, err := AesSivEncrypt(key, []byte(plainText))
+		require.NoError(t, err)
+		require.Equal(t, c1, c2)
+
+		got, err := AesSivDecrypt(key, c1)
+		require.NoError(t, err)
+		require.Equal(t, plainText, string(got))
+	}
+
+	_, err := AesSivEncrypt("short", nil)
+	require.ErrorIs(t, err, ErrSivInvalidKeySize)
+	_, err = AesSivDecrypt(key, base64.StdEncoding.EncodeToString([]byte("short")))
+	require.ErrorIs(t, err, ErrSivAuthFailed)
+}
@@ -0,0 +1,379 @@
+package signature
+
+import (
+	"crypto/elliptic"
+	"crypto/subtle"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// Sm2CipherMode sm2 密文排列方式
+type Sm2CipherMode int
+
+// sm2 cipher mode defined
+const (
+	// Sm2C1C3C2 GB/T 32918.4-2016 新标准
+	Sm2C1C3C2 Sm2CipherMode = iota
+	// Sm2C1C2C3 旧标准
+	Sm2C1C2C3
+)
+
+// Sm2DefaultUID sm2 签名默认的用户身份标识
+var Sm2DefaultUID = []byte("1234567812345678")
+
+// error defined
+var (
+	ErrSm2InvalidPublicKey  = errors.New("sm2: invalid public key")
+	ErrSm2InvalidPrivateKey = errors.New("sm2: invalid private key")
+	ErrSm2InvalidCiphertext = errors.New("sm2: invalid ciphertext")
+	ErrSm2DecryptFailed     = errors.New("sm2: decrypt failed")
+)
+
+var (
+	sm2Param   *elliptic.CurveParams
+	sm2NMinus1 [4]uint64 // 随机数 k 的上限
+	sm2NMinus2 [4]uint64 // 私钥 d 的上限
+)
+
+func init() {
+	hexInt := func(s string) *big.Int {
+		n, _ := new(big.Int).SetString(s, 16)
+		return n
+	}
+	sm2Param = &elliptic.CurveParams{
+		Name:    "SM2-P-256",
+		BitSize: 256,
+		P:       hexInt("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF"),
+		N:       hexInt("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123"),
+		B:       hexInt("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93"),
+		Gx:      hexInt("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7"),
+		Gy:      hexInt("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0"),
+	}
+	sm2NMinus1 = sm2LimbsFromBig(new(big.Int).Sub(sm2Param.N, big.NewInt(1)))
+	sm2NMinus2 = sm2LimbsFromBig(new(big.Int).Sub(sm2Param.N, big.NewInt(2)))
+	initSm2Field(sm2Param)
+}
+
+// Sm2Curve GB/T 32918.5-2017 推荐曲线.
+// 点运算使用常量时间的实现, 见 sm2_curve.go.
+func Sm2Curve() elliptic.Curve {
+	return sm2Curve{sm2Param}
+}
+
+// Sm2PublicKey sm2 公钥
+type Sm2PublicKey struct {
+	X, Y *big.Int
+}
+
+// Sm2PrivateKey sm2 私钥
+type Sm2PrivateKey struct {
+	Sm2PublicKey
+	D *big.Int
+}
+
+// scalar 私钥 d 的 32 字节表示, d 须在 [1, n-2] 内.
+func (pri *Sm2PrivateKey) scalar() ([32]byte, bool) {
+	var b [32]byte
+	if pri == nil || pri.D == nil || pri.D.Sign() <= 0 || pri.D.BitLen() > 256 {
+		return b, false
+	}
+	pri.D.FillBytes(b[:])
+	return b, sm2ValidPrivate(&b)
+}
+
+// point 公钥对应的点, 公钥为空或不在曲线上时返回 false.
+func (pub *Sm2PublicKey) point() (*sm2Point, bool) {
+	if pub == nil {
+		return nil, false
+	}
+	return sm2PointFromBig(pub.X, pub.Y)
+}
+
+// sm2ValidPrivate 常量时间判断 d ∈ [1, n-2]
+func sm2ValidPrivate(d *[32]byte) bool {
+	l := sm2LimbsFromBytes(d)
+	return sm2LimbsIsZero(&l)|sm2LimbsLess(&sm2NMinus2, &l) == 0
+}
+
+// GenerateSm2Key 生成 sm2 密钥对, rand 一般为 crypto/rand.Reader
+func GenerateSm2Key(rand io.Reader) (*Sm2PrivateKey, error) {
+	d, err := sm2RandScalar(rand, &sm2NMinus2)
+	if err != nil {
+		return nil, err
+	}
+	return NewSm2PrivateKey(d[:])
+}
+
+// NewSm2PrivateKey 由私钥 d 构造 sm2 私钥, d ∈ [1, n-2].
+func NewSm2PrivateKey(d []byte) (*Sm2PrivateKey, error) {
+	db, ok := sm2ScalarBytes(d)
+	if !ok || !sm2ValidPrivate(&db) {
+		return nil, ErrSm2InvalidPrivateKey
+	}
+	x, y := sm2ScalarBaseMult(&db)
+	return &Sm2PrivateKey{
+		Sm2PublicKey{new(big.Int).SetBytes(x[:]), new(big.Int).SetBytes(y[:])},
+		new(big.Int).SetBytes(db[:]),
+	}, nil
+}
+
+// Sm2Sign sm2 签名, 输出 ASN.1 DER 编码的 (r, s). uid 为 nil 时使用 Sm2DefaultUID.
+// 涉及私钥和随机数 k 的运算都是常量时间的.
+func Sm2Sign(rand io.Reader, pri *Sm2PrivateKey, msg, uid []byte) ([]byte, error) {
+	d, ok := pri.scalar()
+	if !ok {
+		return nil, ErrSm2InvalidPrivateKey
+	}
+	if _, ok = pri.Sm2PublicKey.point(); !ok {
+		return nil, ErrSm2InvalidPublicKey
+	}
+	digest, err := sm2Digest(&pri.Sm2PublicKey, msg, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	fn := sm2Fn
+	var e, dm, dInv [4]uint64
+	fn.reduce(&e, sm2Wide(sm2LimbsFromBytes(&digest)))
+	dl := sm2LimbsFromBytes(&d)
+	fn.toMont(&dm, &dl)
+	fn.add(&dInv, &fn.one, &dm)
+	fn.inv(&dInv, &dInv) // (1+d)^-1
+	for {
+		k, err := sm2RandScalar(rand, &sm2NMinus1)
+		if err != nil {
+			return nil, err
+		}
+		x1b, _ := sm2ScalarBaseMult(&k)
+		var x1, r, rk, km, rm, s [4]uint64
+		fn.reduce(&x1, sm2Wide(sm2LimbsFromBytes(&x1b)))
+		fn.add(&r, &e, &x1) // r = (e + x1) mod n
+		kl := sm2LimbsFromBytes(&k)
+		fn.add(&rk, &r, &kl)
+		if sm2LimbsIsZero(&r)|sm2LimbsIsZero(&rk) == 1 {
+			continue
+		}
+		// s = (1+d)^-1 * (k - r*d) mod n
+		fn.toMont(&km, &kl)
+		fn.toMont(&rm, &r)
+		fn.mul(&s, &rm, &dm)
+		fn.sub(&s, &km, &s)
+		fn.mul(&s, &s, &dInv)
+		fn.fromMont(&s, &s)
+		if sm2LimbsIsZero(&s) == 1 {
+			continue
+		}
+		rb, sb := sm2LimbsToBytes(&r), sm2LimbsToBytes(&s)
+		return asn1.Marshal(sm2Signature{new(big.Int).SetBytes(rb[:]), new(big.Int).SetBytes(sb[:])})
+	}
+}
+
+// Sm2Verify sm2 验签, sig 为 ASN.1 DER 编码的 (r, s). uid 为 nil 时使用 Sm2DefaultUID.
+// 公钥为空或不在曲线上时返回 false.
+func Sm2Verify(pub *Sm2PublicKey, msg, uid, sig []byte) bool {
+	q, ok := pub.point()
+	if !ok {
+		return false
+	}
+	var rs sm2Signature
+	if rest, err := asn1.Unmarshal(sig, &rs); err != nil || len(rest) != 0 {
+		return false
+	}
+	n := sm2Param.N
+	one := big.NewInt(1)
+	if rs.R == nil || rs.S == nil ||
+		rs.R.Cmp(one) < 0 || rs.R.Cmp(n) >= 0 ||
+		rs.S.Cmp(one) < 0 || rs.S.Cmp(n) >= 0 {
+		return false
+	}
+	digest, err := sm2Digest(pub, msg, uid)
+	if err != nil {
+		return false
+	}
+	t := new(big.Int).Add(rs.R, rs.S)
+	t.Mod(t, n)
+	if t.Sign() == 0 {
+		return false
+	}
+	var sb, tb [32]byte
+	rs.S.FillBytes(sb[:])
+	t.FillBytes(tb[:])
+	var p1, p2 sm2Point
+	p1.scalarMult(&sm2G, &sb)
+	p2.scalarMult(q, &tb)
+	p1.add(&p1, &p2)
+	if p1.isIdentity() == 1 {
+		return false
+	}
+	x1, _ := p1.affine()
+	r := new(big.Int).SetBytes(digest[:])
+	r.Add(r, new(big.Int).SetBytes(x1[:]))
+	r.Mod(r, n)
+	return r.Cmp(rs.R) == 0
+}
+
+// Sm2Encrypt sm2 加密, 并 base64 编码, mode 为密文排列方式.
+// 密文格式: C1(04 || x || y) || C3(32) || C2 或 C1 || C2 || C3
+func Sm2Encrypt(rand io.Reader, pub *Sm2PublicKey, rawText string, mode Sm2CipherMode) (string, error) {
+	q, ok := pub.point()
+	if !ok {
+		return "", ErrSm2InvalidPublicKey
+	}
+	msg := []byte(rawText)
+	for {
+		k, err := sm2RandScalar(rand, &sm2NMinus1)
+		if err != nil {
+			return "", err
+		}
+		x1, y1 := sm2ScalarBaseMult(&k)
+		var p2 sm2Point
+		x2, y2 := p2.scalarMult(q, &k).affine()
+
+		c2, ok := sm2Kdf(append(append([]byte{}, x2[:]...), y2[:]...), len(msg))
+		if !ok {
+			continue
+		}
+		xorBytes(c2, msg)
+		c3 := sm2C3(x2[:], msg, y2[:])
+
+		out := make([]byte, 0, 65+len(c2)+len(c3))
+		out = append(append(append(out, 0x04), x1[:]...), y1[:]...)
+		if mode == Sm2C1C2C3 {
+			out = append(append(out, c2...), c3...)
+		} else {
+			out = append(append(out, c3...), c2...)
+		}
+		return base64.StdEncoding.EncodeToString(out), nil
+	}
+}
+
+// Sm2Decrypt base64 解码并 sm2 解密, mode 为密文排列方式.
+// 私钥无效时返回 ErrSm2InvalidPrivateKey, C1 不是曲线上的点时返回 ErrSm2InvalidCiphertext.
+func Sm2Decrypt(pri *Sm2PrivateKey, cipherText string, mode Sm2CipherMode) (string, error) {
+	d, ok := pri.scalar()
+	if !ok {
+		return "", ErrSm2InvalidPrivateKey
+	}
+	b, err := base64.StdEncoding.DecodeString(cipherText)
+	if err != nil {
+		return "", err
+	}
+	const c1Len = 65
+	if len(b) < c1Len+Sm3Size || b[0] != 0x04 {
+		return "", ErrSm2InvalidCiphertext
+	}
+	var x1, y1 [32]byte
+	copy(x1[:], b[1:33])
+	copy(y1[:], b[33:c1Len])
+	var c1 sm2Point
+	if !c1.setAffine(&x1, &y1) {
+		return "", ErrSm2InvalidCiphertext
+	}
+	var c2, c3 []byte
+	if mode == Sm2C1C2C3 {
+		c2, c3 = b[c1Len:len(b)-Sm3Size], b[len(b)-Sm3Size:]
+	} else {
+		c3, c2 = b[c1Len:c1Len+Sm3Size], b[c1Len+Sm3Size:]
+	}
+
+	var p2 sm2Point
+	x2, y2 := p2.scalarMult(&c1, &d).affine()
+	msg, ok := sm2Kdf(append(append([]byte{}, x2[:]...), y2[:]...), len(c2))
+	if !ok {
+		return "", ErrSm2DecryptFailed
+	}
+	xorBytes(msg, c2)
+	if subtle.ConstantTimeCompare(c3, sm2C3(x2[:], msg, y2[:])) != 1 {
+		return "", ErrSm2DecryptFailed
+	}
+	return string(msg), nil
+}
+
+type sm2Signature struct {
+	R, S *big.Int
+}
+
+// sm2Digest e = SM3(Z_A || M), Z_A = SM3(ENTL_A || ID_A || a || b || x_G || y_G || x_A || y_A)
+// 公钥须已校验在曲线上.
+func sm2Digest(pub *Sm2PublicKey, msg, uid []byte) ([32]byte, error) {
+	var e [32]byte
+	if uid == nil {
+		uid = Sm2DefaultUID
+	}
+	if len(uid) >= 8192 {
+		return e, errors.New("sm2: uid too long")
+	}
+	params := sm2Param
+	a := new(big.Int).Sub(params.P, big.NewInt(3))
+
+	h := NewSm3()
+	entl := uint16(len(uid) * 8)
+	h.Write([]byte{byte(entl >> 8), byte(entl)})
+	h.Write(uid)
+	for _, v := range []*big.Int{a, params.B, params.Gx, params.Gy, pub.X, pub.Y} {
+		h.Write(sm2PadBytes(v))
+	}
+	za := h.Sum(nil)
+
+	h.Reset()
+	h.Write(za)
+	h.Write(msg)
+	h.Sum(e[:0])
+	return e, nil
+}
+
+// sm2Kdf GB/T 32918.4 密钥派生函数, 派生结果全为 0 时返回 false
+func sm2Kdf(z []byte, kLen int) ([]byte, bool) {
+	out := make([]byte, 0, kLen+Sm3Size)
+	var ct [4]byte
+	h := NewSm3()
+	for i := uint32(1); len(out) < kLen; i++ {
+		binary.BigEndian.PutUint32(ct[:], i)
+		h.Reset()
+		h.Write(z)
+		h.Write(ct[:])
+		out = h.Sum(out)
+	}
+	out = out[:kLen]
+	for _, v := range out {
+		if v != 0 {
+			return out, true
+		}
+	}
+	return out, kLen == 0
+}
+
+func sm2C3(x2, msg, y2 []byte) []byte {
+	h := NewSm3()
+	h.Write(x2)
+	h.Write(msg)
+	h.Write(y2)
+	return h.Sum(nil)
+}
+
+// sm2RandScalar 随机数 k ∈ [1, max], 拒绝采样, 接受的值与时间无关.
+func sm2RandScalar(rand io.Reader, max *[4]uint64) ([32]byte, error) {
+	var b [32]byte
+	for {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return b, err
+		}
+		k := sm2LimbsFromBytes(&b)
+		if sm2LimbsIsZero(&k)|sm2LimbsLess(max, &k) == 0 {
+			return b, nil
+		}
+	}
+}
+
+// sm2Wide 扩展为 reduce 的参数, x < 2^256 < 2n
+func sm2Wide(x [4]uint64) *[5]uint64 {
+	return &[5]uint64{x[0], x[1], x[2], x[3], 0}
+}
+
+func sm2PadBytes(v *big.Int) []byte {
+	return v.FillBytes(make([]byte, 32))
+}
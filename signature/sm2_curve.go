@@ -0,0 +1,432 @@
+package signature
+
+import (
+	"crypto/elliptic"
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// sm2 曲线的常量时间实现.
+// 域元素和标量为 4 个 64 位的小端序字, 使用 Montgomery 形式运算, 所有运算不依赖数据分支或查表,
+// 点运算使用 a = -3 的完备加法公式(Renes-Costello-Batina 2015, Algorithm 4), 无需处理无穷远点和倍点的特殊情况.
+
+// montField 模 m 的 Montgomery 域, m 为奇数且大于 2^255, R = 2^256.
+type montField struct {
+	m       [4]uint64
+	m0inv   uint64    // -m^-1 mod 2^64
+	rr      [4]uint64 // R^2 mod m
+	one     [4]uint64 // R mod m
+	mMinus2 [4]uint64 // 求逆的指数 m-2
+}
+
+func newMontField(m *big.Int) *montField {
+	f := &montField{m: sm2LimbsFromBig(m)}
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.m[0]*inv
+	}
+	f.m0inv = -inv
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	f.one = sm2LimbsFromBig(new(big.Int).Mod(r, m))
+	f.rr = sm2LimbsFromBig(new(big.Int).Mod(new(big.Int).Mul(r, r), m))
+	f.mMinus2 = sm2LimbsFromBig(new(big.Int).Sub(m, big.NewInt(2)))
+	return f
+}
+
+// reduce t(t[4] 为进位) < 2m 时返回 t mod m
+func (f *montField) reduce(z *[4]uint64, t *[5]uint64) {
+	var d [4]uint64
+	var b uint64
+	d[0], b = bits.Sub64(t[0], f.m[0], 0)
+	d[1], b = bits.Sub64(t[1], f.m[1], b)
+	d[2], b = bits.Sub64(t[2], f.m[2], b)
+	d[3], b = bits.Sub64(t[3], f.m[3], b)
+	_, b = bits.Sub64(t[4], 0, b)
+	// b 为 1 表示 t < m, 保留 t
+	mask := -b
+	for i := range z {
+		z[i] = t[i]&mask | d[i]&^mask
+	}
+}
+
+// add z = x + y mod m
+func (f *montField) add(z, x, y *[4]uint64) {
+	var t [5]uint64
+	var c uint64
+	t[0], c = bits.Add64(x[0], y[0], 0)
+	t[1], c = bits.Add64(x[1], y[1], c)
+	t[2], c = bits.Add64(x[2], y[2], c)
+	t[3], c = bits.Add64(x[3], y[3], c)
+	t[4] = c
+	f.reduce(z, &t)
+}
+
+// sub z = x - y mod m
+func (f *montField) sub(z, x, y *[4]uint64) {
+	var d [4]uint64
+	var b uint64
+	d[0], b = bits.Sub64(x[0], y[0], 0)
+	d[1], b = bits.Sub64(x[1], y[1], b)
+	d[2], b = bits.Sub64(x[2], y[2], b)
+	d[3], b = bits.Sub64(x[3], y[3], b)
+	mask := -b
+	var c uint64
+	z[0], c = bits.Add64(d[0], f.m[0]&mask, 0)
+	z[1], c = bits.Add64(d[1], f.m[1]&mask, c)
+	z[2], c = bits.Add64(d[2], f.m[2]&mask, c)
+	z[3], _ = bits.Add64(d[3], f.m[3]&mask, c)
+}
+
+// mul z = x * y * R^-1 mod m, CIOS Montgomery 乘法
+func (f *montField) mul(z, x, y *[4]uint64) {
+	var t [6]uint64
+	for i := 0; i < 4; i++ {
+		var c, cc, hi, lo uint64
+		for j := 0; j < 4; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		t[4], cc = bits.Add64(t[4], c, 0)
+		t[5] = cc
+
+		mm := t[0] * f.m0inv
+		hi, lo = bits.Mul64(mm, f.m[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(mm, f.m[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+	f.reduce(z, &[5]uint64{t[0], t[1], t[2], t[3], t[4]})
+}
+
+// exp z = x^e, e 为公开的指数, 运算时间只与 e 有关.
+func (f *montField) exp(z, x, e *[4]uint64) {
+	r := f.one
+	for i := 3; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			f.mul(&r, &r, &r)
+			if (e[i]>>uint(j))&1 == 1 {
+				f.mul(&r, &r, x)
+			}
+		}
+	}
+	*z = r
+}
+
+// inv z = x^-1, x 为 0 时 z 为 0.
+func (f *montField) inv(z, x *[4]uint64) { f.exp(z, x, &f.mMinus2) }
+
+// toMont z = x * R mod m, x 须小于 m.
+func (f *montField) toMont(z, x *[4]uint64) { f.mul(z, x, &f.rr) }
+
+// fromMont z = x * R^-1 mod m
+func (f *montField) fromMont(z, x *[4]uint64) { f.mul(z, x, &[4]uint64{1}) }
+
+// less 常量时间比较 x < m
+func (f *montField) less(x *[4]uint64) uint64 { return sm2LimbsLess(x, &f.m) }
+
+// sm2LimbsLess 常量时间比较 x < y, 是返回 1
+func sm2LimbsLess(x, y *[4]uint64) uint64 {
+	var b uint64
+	_, b = bits.Sub64(x[0], y[0], 0)
+	_, b = bits.Sub64(x[1], y[1], b)
+	_, b = bits.Sub64(x[2], y[2], b)
+	_, b = bits.Sub64(x[3], y[3], b)
+	return b
+}
+
+// sm2LimbsIsZero 常量时间判断 x 是否为 0, 是返回 1
+func sm2LimbsIsZero(x *[4]uint64) uint64 {
+	v := x[0] | x[1] | x[2] | x[3]
+	return 1 ^ (v|-v)>>63
+}
+
+// sm2LimbsSelect cond 为 1 时 z = x, 否则不变
+func sm2LimbsSelect(z, x *[4]uint64, cond uint64) {
+	mask := -cond
+	for i := range z {
+		z[i] = x[i]&mask | z[i]&^mask
+	}
+}
+
+func sm2LimbsFromBytes(b *[32]byte) [4]uint64 {
+	return [4]uint64{
+		binary.BigEndian.Uint64(b[24:]),
+		binary.BigEndian.Uint64(b[16:]),
+		binary.BigEndian.Uint64(b[8:]),
+		binary.BigEndian.Uint64(b[:8]),
+	}
+}
+
+func sm2LimbsToBytes(x *[4]uint64) [32]byte {
+	var b [32]byte
+	binary.BigEndian.PutUint64(b[24:], x[0])
+	binary.BigEndian.PutUint64(b[16:], x[1])
+	binary.BigEndian.PutUint64(b[8:], x[2])
+	binary.BigEndian.PutUint64(b[:8], x[3])
+	return b
+}
+
+// sm2LimbsFromBig 用于常量和公开的值, v 须为 [0, 2^256).
+func sm2LimbsFromBig(v *big.Int) [4]uint64 {
+	var b [32]byte
+	v.FillBytes(b[:])
+	return sm2LimbsFromBytes(&b)
+}
+
+var (
+	sm2Fp *montField // 模 p 的域
+	sm2Fn *montField // 模 n 的标量域
+	sm2B  [4]uint64  // 曲线参数 b, Montgomery 形式
+	sm2G  sm2Point   // 基点
+)
+
+func initSm2Field(params *elliptic.CurveParams) {
+	sm2Fp = newMontField(params.P)
+	sm2Fn = newMontField(params.N)
+	b := sm2LimbsFromBig(params.B)
+	sm2Fp.toMont(&sm2B, &b)
+	var gx, gy [32]byte
+	params.Gx.FillBytes(gx[:])
+	params.Gy.FillBytes(gy[:])
+	if !sm2G.setAffine(&gx, &gy) {
+		panic("sm2: invalid base point")
+	}
+}
+
+// sm2Point 射影坐标 (X:Y:Z) 的点, 坐标为 Montgomery 形式, 无穷远点为 (0:1:0).
+type sm2Point struct {
+	x, y, z [4]uint64
+}
+
+func (p *sm2Point) setIdentity() *sm2Point {
+	p.x, p.y, p.z = [4]uint64{}, sm2Fp.one, [4]uint64{}
+	return p
+}
+
+// setAffine 由仿射坐标设置点, 坐标须小于 p 且点在曲线上, 否则返回 false.
+func (p *sm2Point) setAffine(xb, yb *[32]byte) bool {
+	x, y := sm2LimbsFromBytes(xb), sm2LimbsFromBytes(yb)
+	if sm2Fp.less(&x)&sm2Fp.less(&y) != 1 {
+		return false
+	}
+	sm2Fp.toMont(&p.x, &x)
+	sm2Fp.toMont(&p.y, &y)
+	p.z = sm2Fp.one
+	// y^2 = x^3 - 3x + b
+	var lhs, rhs, t [4]uint64
+	sm2Fp.mul(&lhs, &p.y, &p.y)
+	sm2Fp.mul(&rhs, &p.x, &p.x)
+	sm2Fp.mul(&rhs, &rhs, &p.x)
+	sm2Fp.add(&t, &p.x, &p.x)
+	sm2Fp.add(&t, &t, &p.x)
+	sm2Fp.sub(&rhs, &rhs, &t)
+	sm2Fp.add(&rhs, &rhs, &sm2B)
+	return lhs == rhs
+}
+
+// affine 仿射坐标, 无穷远点为 (0, 0).
+func (p *sm2Point) affine() (x, y [32]byte) {
+	var zinv, ax, ay [4]uint64
+	sm2Fp.inv(&zinv, &p.z)
+	sm2Fp.mul(&ax, &p.x, &zinv)
+	sm2Fp.mul(&ay, &p.y, &zinv)
+	sm2Fp.fromMont(&ax, &ax)
+	sm2Fp.fromMont(&ay, &ay)
+	return sm2LimbsToBytes(&ax), sm2LimbsToBytes(&ay)
+}
+
+// isIdentity 是否为无穷远点, 是返回 1
+func (p *sm2Point) isIdentity() uint64 { return sm2LimbsIsZero(&p.z) }
+
+// add r = p + q, 完备加法公式, p, q 可以相同或为无穷远点.
+func (r *sm2Point) add(p, q *sm2Point) *sm2Point {
+	f := sm2Fp
+	var t0, t1, t2, t3, t4, x3, y3, z3 [4]uint64
+	f.mul(&t0, &p.x, &q.x)
+	f.mul(&t1, &p.y, &q.y)
+	f.mul(&t2, &p.z, &q.z)
+	f.add(&t3, &p.x, &p.y)
+	f.add(&t4, &q.x, &q.y)
+	f.mul(&t3, &t3, &t4)
+	f.add(&t4, &t0, &t1)
+	f.sub(&t3, &t3, &t4)
+	f.add(&t4, &p.y, &p.z)
+	f.add(&x3, &q.y, &q.z)
+	f.mul(&t4, &t4, &x3)
+	f.add(&x3, &t1, &t2)
+	f.sub(&t4, &t4, &x3)
+	f.add(&x3, &p.x, &p.z)
+	f.add(&y3, &q.x, &q.z)
+	f.mul(&x3, &x3, &y3)
+	f.add(&y3, &t0, &t2)
+	f.sub(&y3, &x3, &y3)
+	f.mul(&z3, &sm2B, &t2)
+	f.sub(&x3, &y3, &z3)
+	f.add(&z3, &x3, &x3)
+	f.add(&x3, &x3, &z3)
+	f.sub(&z3, &t1, &x3)
+	f.add(&x3, &t1, &x3)
+	f.mul(&y3, &sm2B, &y3)
+	f.add(&t1, &t2, &t2)
+	f.add(&t2, &t1, &t2)
+	f.sub(&y3, &y3, &t2)
+	f.sub(&y3, &y3, &t0)
+	f.add(&t1, &y3, &y3)
+	f.add(&y3, &t1, &y3)
+	f.add(&t1, &t0, &t0)
+	f.add(&t0, &t1, &t0)
+	f.sub(&t0, &t0, &t2)
+	f.mul(&t1, &t4, &y3)
+	f.mul(&t2, &t0, &y3)
+	f.mul(&y3, &x3, &z3)
+	f.add(&y3, &y3, &t2)
+	f.mul(&x3, &t3, &x3)
+	f.sub(&x3, &x3, &t1)
+	f.mul(&z3, &t4, &z3)
+	f.mul(&t1, &t3, &t0)
+	f.add(&z3, &z3, &t1)
+	r.x, r.y, r.z = x3, y3, z3
+	return r
+}
+
+// scalarMult r = k * q, 4 位固定窗口, 每个窗口都执行相同的运算并常量时间查表.
+func (r *sm2Point) scalarMult(q *sm2Point, k *[32]byte) *sm2Point {
+	var table [16]sm2Point
+	table[0].setIdentity()
+	table[1] = *q
+	for i := 2; i < 16; i++ {
+		table[i].add(&table[i-1], q)
+	}
+
+	var acc, t sm2Point
+	acc.setIdentity()
+	for _, b := range k {
+		for _, w := range [2]byte{b >> 4, b & 0x0f} {
+			for i := 0; i < 4; i++ {
+				acc.add(&acc, &acc)
+			}
+			t.setIdentity()
+			for i := range table {
+				eq := sm2CtEq(uint64(i), uint64(w))
+				sm2LimbsSelect(&t.x, &table[i].x, eq)
+				sm2LimbsSelect(&t.y, &table[i].y, eq)
+				sm2LimbsSelect(&t.z, &table[i].z, eq)
+			}
+			acc.add(&acc, &t)
+		}
+	}
+	*r = acc
+	return r
+}
+
+// sm2CtEq 常量时间比较 x == y, 是返回 1
+func sm2CtEq(x, y uint64) uint64 {
+	v := x ^ y
+	return 1 ^ (v|-v)>>63
+}
+
+// sm2ScalarBaseMult k * G 的仿射坐标
+func sm2ScalarBaseMult(k *[32]byte) (x, y [32]byte) {
+	var p sm2Point
+	return p.scalarMult(&sm2G, k).affine()
+}
+
+// sm2PointFromBig 由公开的仿射坐标构造点, 坐标为 nil, 负数, 不小于 p 或点不在曲线上时返回 false.
+func sm2PointFromBig(x, y *big.Int) (*sm2Point, bool) {
+	if x == nil || y == nil || x.Sign() < 0 || y.Sign() < 0 || x.BitLen() > 256 || y.BitLen() > 256 {
+		return nil, false
+	}
+	var xb, yb [32]byte
+	x.FillBytes(xb[:])
+	y.FillBytes(yb[:])
+	p := new(sm2Point)
+	if !p.setAffine(&xb, &yb) {
+		return nil, false
+	}
+	return p, true
+}
+
+// sm2ScalarBytes 将标量转换为 32 字节, 超出 32 字节时返回 false.
+func sm2ScalarBytes(k []byte) ([32]byte, bool) {
+	var b [32]byte
+	for len(k) > 32 {
+		if k[0] != 0 {
+			return b, false
+		}
+		k = k[1:]
+	}
+	copy(b[32-len(k):], k)
+	return b, true
+}
+
+// sm2Curve 实现 elliptic.Curve, 运算使用常量时间的实现.
+type sm2Curve struct {
+	params *elliptic.CurveParams
+}
+
+func (c sm2Curve) Params() *elliptic.CurveParams { return c.params }
+
+func (c sm2Curve) IsOnCurve(x, y *big.Int) bool {
+	_, ok := sm2PointFromBig(x, y)
+	return ok
+}
+
+// point 转换为点, (0, 0) 为无穷远点, 不在曲线上时 panic, 同标准库.
+func (c sm2Curve) point(x, y *big.Int) *sm2Point {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return new(sm2Point).setIdentity()
+	}
+	p, ok := sm2PointFromBig(x, y)
+	if !ok {
+		panic("sm2: invalid curve point")
+	}
+	return p
+}
+
+func (c sm2Curve) affine(p *sm2Point) (*big.Int, *big.Int) {
+	x, y := p.affine()
+	return new(big.Int).SetBytes(x[:]), new(big.Int).SetBytes(y[:])
+}
+
+func (c sm2Curve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	return c.affine(new(sm2Point).add(c.point(x1, y1), c.point(x2, y2)))
+}
+
+func (c sm2Curve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	p := c.point(x1, y1)
+	return c.affine(new(sm2Point).add(p, p))
+}
+
+func (c sm2Curve) ScalarMult(x1, y1 *big.Int, k []byte) (*big.Int, *big.Int) {
+	kb := c.scalar(k)
+	return c.affine(new(sm2Point).scalarMult(c.point(x1, y1), &kb))
+}
+
+func (c sm2Curve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	kb := c.scalar(k)
+	return c.affine(new(sm2Point).scalarMult(&sm2G, &kb))
+}
+
+// scalar 超出 32 字节的标量先对 n 取模, 同标准库.
+func (c sm2Curve) scalar(k []byte) [32]byte {
+	kb, ok := sm2ScalarBytes(k)
+	if !ok {
+		v := new(big.Int).Mod(new(big.Int).SetBytes(k), c.params.N)
+		v.FillBytes(kb[:])
+	}
+	return kb
+}
@@ -0,0 +1,200 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSm2Curve(t *testing.T) {
+	params := Sm2Curve().Params()
+	require.True(t, Sm2Curve().IsOnCurve(params.Gx, params.Gy))
+	x, y := Sm2Curve().ScalarBaseMult(params.N.Bytes())
+	require.Zero(t, x.Sign())
+	require.Zero(t, y.Sign())
+
+	// 与通用实现对比
+	for i := 0; i < 16; i++ {
+		k := make([]byte, 32)
+		_, err := rand.Read(k)
+		require.NoError(t, err)
+		x1, y1 := Sm2Curve().ScalarBaseMult(k)
+		x2, y2 := params.ScalarBaseMult(k)
+		require.Equal(t, x2, x1)
+		require.Equal(t, y2, y1)
+
+		x3, y3 := Sm2Curve().ScalarMult(x1, y1, k)
+		x4, y4 := params.ScalarMult(x1, y1, k)
+		require.Equal(t, x4, x3)
+		require.Equal(t, y4, y3)
+
+		x3, y3 = Sm2Curve().Add(x1, y1, params.Gx, params.Gy)
+		x4, y4 = params.Add(x1, y1, params.Gx, params.Gy)
+		require.Equal(t, x4, x3)
+		require.Equal(t, y4, y3)
+
+		x3, y3 = Sm2Curve().Double(x1, y1)
+		x4, y4 = params.Double(x1, y1)
+		require.Equal(t, x4, x3)
+		require.Equal(t, y4, y3)
+	}
+	x, y = Sm2Curve().Add(params.Gx, params.Gy, params.Gx, params.Gy)
+	x1, y1 := Sm2Curve().Double(params.Gx, params.Gy)
+	require.Equal(t, x1, x)
+	require.Equal(t, y1, y)
+
+	require.False(t, Sm2Curve().IsOnCurve(params.Gx, new(big.Int).Add(params.Gy, big.NewInt(1))))
+	require.False(t, Sm2Curve().IsOnCurve(new(big.Int).Add(params.Gx, params.P), params.Gy))
+	require.Panics(t, func() { Sm2Curve().ScalarMult(params.Gx, big.NewInt(1), []byte{1}) })
+}
+
+func TestSm2InvalidKey(t *testing.T) {
+	pri, err := GenerateSm2Key(rand.Reader)
+	require.NoError(t, err)
+	msg := []byte("message digest")
+	sig, err := Sm2Sign(rand.Reader, pri, msg, nil)
+	require.NoError(t, err)
+	cipherText, err := Sm2Encrypt(rand.Reader, &pri.Sm2PublicKey, "encryption standard", Sm2C1C3C2)
+	require.NoError(t, err)
+
+	params := Sm2Curve().Params()
+	for _, pub := range []*Sm2PublicKey{
+		nil,
+		{},
+		{X: pri.X, Y: new(big.Int).Add(pri.Y, big.NewInt(1))},
+		{X: new(big.Int).Add(pri.X, params.P), Y: pri.Y},
+		{X: new(big.Int).Lsh(big.NewInt(1), 300), Y: pri.Y},
+	} {
+		require.False(t, Sm2Verify(pub, msg, nil, sig))
+		_, err = Sm2Encrypt(rand.Reader, pub, "x", Sm2C1C3C2)
+		require.ErrorIs(t, err, ErrSm2InvalidPublicKey)
+	}
+
+	for _, k := range []*Sm2PrivateKey{
+		nil,
+		{},
+		{Sm2PublicKey: pri.Sm2PublicKey},
+		{Sm2PublicKey: pri.Sm2PublicKey, D: new(big.Int).Set(params.N)},
+	} {
+		_, err = Sm2Sign(rand.Reader, k, msg, nil)
+		require.Error(t, err)
+		_, err = Sm2Decrypt(k, cipherText, Sm2C1C3C2)
+		require.ErrorIs(t, err, ErrSm2InvalidPrivateKey)
+	}
+
+	// C1 不在曲线上
+	b, err := base64.StdEncoding.DecodeString(cipherText)
+	require.NoError(t, err)
+	b[64] ^= 1
+	_, err = Sm2Decrypt(pri, base64.StdEncoding.EncodeToString(b), Sm2C1C3C2)
+	require.ErrorIs(t, err, ErrSm2InvalidCiphertext)
+	b[64] ^= 1
+	b[0] = 0x02
+	_, err = Sm2Decrypt(pri, base64.StdEncoding.EncodeToString(b), Sm2C1C3C2)
+	require.ErrorIs(t, err, ErrSm2InvalidCiphertext)
+}
+
+func TestSm2SignVerify(t *testing.T) {
+	pri, err := GenerateSm2Key(rand.Reader)
+	require.NoError(t, err)
+	require.True(t, Sm2Curve().IsOnCurve(pri.X, pri.Y))
+
+	msg := []byte("message digest")
+	sig, err := Sm2Sign(rand.Reader, pri, msg, nil)
+	require.NoError(t, err)
+	require.True(t, Sm2Verify(&pri.Sm2PublicKey, msg, nil, sig))
+	require.True(t, Sm2Verify(&pri.Sm2PublicKey, msg, Sm2DefaultUID, sig))
+	require.False(t, Sm2Verify(&pri.Sm2PublicKey, []byte("message digesT"), nil, sig))
+	require.False(t, Sm2Verify(&pri.Sm2PublicKey, msg, []byte("ALICE123@YAHOO.COM"), sig))
+	require.False(t, Sm2Verify(&pri.Sm2PublicKey, msg, nil, sig[1:]))
+
+	other, err := GenerateSm2Key(rand.Reader)
+	require.NoError(t, err)
+	require.False(t, Sm2Verify(&other.Sm2PublicKey, msg, nil, sig))
+
+	uid := []byte("ALICE123@YAHOO.COM")
+	sig, err = Sm2Sign(rand.Reader, pri, msg, uid)
+	require.NoError(t, err)
+	require.True(t, Sm2Verify(&pri.Sm2PublicKey, msg, uid, sig))
+}
+
+func TestSm2EncryptDecrypt(t *testing.T) {
+	pri, err := GenerateSm2Key(rand.Reader)
+	require.NoError(t, err)
+
+	want := "encryption standard"
+	for _, mode := range []Sm2CipherMode{Sm2C1C3C2, Sm2C1C2C3} {
+		cipherText, err := Sm2Encrypt(rand.Reader, &pri.Sm2PublicKey, want, mode)
+		require.NoError(t, err)
+		b, err := base64.StdEncoding.DecodeString(cipherText)
+		require.NoError(t, err)
+		require.Len(t, b, 65+32+len(want))
+		require.Equal(t, byte(0x04), b[0])
+
+		got, err := Sm2Decrypt(pri, cipherText, mode)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+
+		// 排列方式不一致
+		_, err = Sm2Decrypt(pri, cipherText, 1-mode)
+		require.ErrorIs(t, err, ErrSm2DecryptFailed)
+
+		b[len(b)-1] ^= 0xff
+		_, err = Sm2Decrypt(pri, base64.StdEncoding.EncodeToString(b), mode)
+		require.ErrorIs(t, err, ErrSm2DecryptFailed)
+	}
+
+	_, err = Sm2Decrypt(pri, base64.StdEncoding.EncodeToString([]byte("short")), Sm2C1C3C2)
+	require.ErrorIs(t, err, ErrSm2InvalidCiphertext)
+	_, err = Sm2Encrypt(rand.Reader, &Sm2PublicKey{}, want, Sm2C1C3C2)
+	require.ErrorIs(t, err, ErrSm2InvalidPublicKey)
+}
+
+func TestNewSm2PrivateKey(t *testing.T) {
+	d := mustHex("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8")
+	pri, err := NewSm2PrivateKey(d)
+	require.NoError(t, err)
+	require.Equal(t, "09f9df311e5421a150dd7d161e4bc5c672179fad1833fc076bb08ff356f35020", hexPad(pri.X.Bytes()))
+	require.Equal(t, "ccea490ce26775a52dc6ea718cc1aa600aed05fbf35e084a6632f6072da9ad13", hexPad(pri.Y.Bytes()))
+
+	_, err = NewSm2PrivateKey([]byte{0})
+	require.Error(t, err)
+}
+
+func TestSm2KnownAnswer(t *testing.T) {
+	// GM/T 0003.5-2012 示例, 推荐曲线, 固定随机数 k
+	pri, err := NewSm2PrivateKey(mustHex("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8"))
+	require.NoError(t, err)
+	fixedK := func() *bytes.Reader {
+		return bytes.NewReader(mustHex("59276E27D506861A16680F3AD9C02DCCEF3CC1FA3CDBE4CE6D54B80DEAC1BC21"))
+	}
+
+	sig, err := Sm2Sign(fixedK(), pri, []byte("message digest"), nil)
+	require.NoError(t, err)
+	var rs sm2Signature
+	_, err = asn1.Unmarshal(sig, &rs)
+	require.NoError(t, err)
+	require.Equal(t, "f5a03b0648d2c4630eeac513e1bb81a15944da3827d5b74143ac7eaceee720b3", hexPad(rs.R.Bytes()))
+	require.Equal(t, "b1b6aa29df212fd8763182bc0d421ca1bb9038fd1f7f42d4840b69c485bbc1aa", hexPad(rs.S.Bytes()))
+
+	cipherText, err := Sm2Encrypt(fixedK(), &pri.Sm2PublicKey, "encryption standard", Sm2C1C3C2)
+	require.NoError(t, err)
+	want := mustHex("04" +
+		"04ebfc718e8d1798620432268e77feb6415e2ede0e073c0f4f640ecd2e149a73" +
+		"e858f9d81e5430a57b36daab8f950a3c64e6ee6a63094d99283aff767e124df0" +
+		"59983c18f809e262923c53aec295d30383b54e39d609d160afcb1908d0bd8766" +
+		"21886ca989ca9c7d58087307ca93092d651efa")
+	require.Equal(t, base64.StdEncoding.EncodeToString(want), cipherText)
+	got, err := Sm2Decrypt(pri, cipherText, Sm2C1C3C2)
+	require.NoError(t, err)
+	require.Equal(t, "encryption standard", got)
+}
+
+func hexPad(b []byte) string {
+	return EncodingHex.EncodeToString(append(make([]byte, 32-len(b)), b...))
+}
@@ -0,0 +1,155 @@
+package signature
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"math/bits"
+)
+
+// Sm3Size sm3 摘要长度
+const Sm3Size = 32
+
+const sm3BlockSize = 64
+
+var sm3IV = [8]uint32{
+	0x7380166f, 0x4914b2b9, 0x172442d7, 0xda8a0600,
+	0xa96f30bc, 0x163138aa, 0xe38dee4d, 0xb0fb0e4e,
+}
+
+// sm3Digest GB/T 32905-2016 SM3 密码杂凑算法
+type sm3Digest struct {
+	h   [8]uint32
+	x   [sm3BlockSize]byte
+	nx  int
+	len uint64
+}
+
+// NewSm3 returns a new hash.Hash computing the SM3 checksum.
+func NewSm3() hash.Hash {
+	d := new(sm3Digest)
+	d.Reset()
+	return d
+}
+
+// Sm3Sum returns the SM3 checksum of the data.
+func Sm3Sum(data []byte) [Sm3Size]byte {
+	d := new(sm3Digest)
+	d.Reset()
+	d.Write(data)
+	var out [Sm3Size]byte
+	copy(out[:], d.Sum(nil))
+	return out
+}
+
+// HexSm3 sm3 with hex encoded.
+func HexSm3(str string) string {
+	bs := Sm3Sum([]byte(str))
+	return hex.EncodeToString(bs[:])
+}
+
+// HmacSm3 hmac sm3 with base64 encoded.
+func HmacSm3(key, str string) string {
+	return base64.StdEncoding.EncodeToString(Hmac(NewSm3, []byte(key), []byte(str)))
+}
+
+func (d *sm3Digest) Size() int      { return Sm3Size }
+func (d *sm3Digest) BlockSize() int { return sm3BlockSize }
+
+func (d *sm3Digest) Reset() {
+	d.h = sm3IV
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *sm3Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+	if d.nx > 0 {
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		if d.nx == sm3BlockSize {
+			sm3Block(&d.h, d.x[:])
+			d.nx = 0
+		}
+		p = p[c:]
+	}
+	for len(p) >= sm3BlockSize {
+		sm3Block(&d.h, p[:sm3BlockSize])
+		p = p[sm3BlockSize:]
+	}
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+	return n, nil
+}
+
+func (d *sm3Digest) Sum(in []byte) []byte {
+	// 复制一份, 使调用者可以继续写入
+	d0 := *d
+	length := d0.len << 3
+
+	var tmp [sm3BlockSize + 8]byte
+	tmp[0] = 0x80
+	padLen := 56 - int(d0.len%sm3BlockSize)
+	if padLen <= 0 {
+		padLen += sm3BlockSize
+	}
+	binary.BigEndian.PutUint64(tmp[padLen:], length)
+	d0.Write(tmp[:padLen+8])
+
+	out := make([]byte, Sm3Size)
+	for i, v := range d0.h {
+		binary.BigEndian.PutUint32(out[i*4:], v)
+	}
+	return append(in, out...)
+}
+
+func sm3P0(x uint32) uint32 { return x ^ bits.RotateLeft32(x, 9) ^ bits.RotateLeft32(x, 17) }
+func sm3P1(x uint32) uint32 { return x ^ bits.RotateLeft32(x, 15) ^ bits.RotateLeft32(x, 23) }
+
+func sm3Block(h *[8]uint32, p []byte) {
+	var w [68]uint32
+	for i := 0; i < 16; i++ {
+		w[i] = binary.BigEndian.Uint32(p[i*4:])
+	}
+	for i := 16; i < 68; i++ {
+		w[i] = sm3P1(w[i-16]^w[i-9]^bits.RotateLeft32(w[i-3], 15)) ^ bits.RotateLeft32(w[i-13], 7) ^ w[i-6]
+	}
+
+	a, b, c, d, e, f, g, hh := h[0], h[1], h[2], h[3], h[4], h[5], h[6], h[7]
+	for j := 0; j < 64; j++ {
+		var tj, ff, gg uint32
+		if j < 16 {
+			tj = 0x79cc4519
+			ff = a ^ b ^ c
+			gg = e ^ f ^ g
+		} else {
+			tj = 0x7a879d8a
+			ff = (a & b) | (a & c) | (b & c)
+			gg = (e & f) | (^e & g)
+		}
+		a12 := bits.RotateLeft32(a, 12)
+		ss1 := bits.RotateLeft32(a12+e+bits.RotateLeft32(tj, j%32), 7)
+		ss2 := ss1 ^ a12
+		tt1 := ff + d + ss2 + (w[j] ^ w[j+4])
+		tt2 := gg + hh + ss1 + w[j]
+		d = c
+		c = bits.RotateLeft32(b, 9)
+		b = a
+		a = tt1
+		hh = g
+		g = bits.RotateLeft32(f, 19)
+		f = e
+		e = sm3P0(tt2)
+	}
+	h[0] ^= a
+	h[1] ^= b
+	h[2] ^= c
+	h[3] ^= d
+	h[4] ^= e
+	h[5] ^= f
+	h[6] ^= g
+	h[7] ^= hh
+}
@@ -0,0 +1,57 @@
+package signature
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSm3(t *testing.T) {
+	// GB/T 32905-2016 附录 A
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+		{strings.Repeat("abcd", 16), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, HexSm3(tt.input))
+
+		// 分段写入
+		h := NewSm3()
+		for i := 0; i < len(tt.input); i++ {
+			h.Write([]byte{tt.input[i]})
+		}
+		require.Equal(t, tt.want, hex.EncodeToString(h.Sum(nil)))
+		require.Equal(t, tt.want, hex.EncodeToString(h.Sum(nil)))
+	}
+
+	h := NewSm3()
+	require.Equal(t, Sm3Size, h.Size())
+	require.Equal(t, 64, h.BlockSize())
+	h.Write([]byte("abc"))
+	h.Reset()
+	require.Equal(t, HexSm3(""), hex.EncodeToString(h.Sum(nil)))
+	require.Equal(t, "1ab21d8355cfa17f8e61194831e81a8f22bec8c728fefb747ed035eb5082aa2b", HexSm3(""))
+
+	got, err := HashReader(NewSm3, strings.NewReader(strings.Repeat("a", 1000)), EncodingHex)
+	require.NoError(t, err)
+	require.Equal(t, HexSm3(strings.Repeat("a", 1000)), got)
+}
+
+func TestHmacSm3(t *testing.T) {
+	require.Equal(t, HmacSm3("key", "abc"), HmacSm3("key", "abc"))
+	require.NotEqual(t, HmacSm3("key", "abc"), HmacSm3("key1", "abc"))
+	require.Len(t, Hmac(NewSm3, []byte("key"), []byte("abc")), Sm3Size)
+}
+
+func BenchmarkSm3(b *testing.B) {
+	data := []byte(strings.Repeat("a", 1024))
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		Sm3Sum(data)
+	}
+}
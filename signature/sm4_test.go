@@ -0,0 +1,61 @@
+package signature
+
+import (
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSm4Cipher(t *testing.T) {
+	// GB/T 32907-2016 附录 A
+	key := mustHex("0123456789abcdeffedcba9876543210")
+	plainText := mustHex("0123456789abcdeffedcba9876543210")
+
+	c, err := NewSm4Cipher(key)
+	require.NoError(t, err)
+	require.Equal(t, Sm4BlockSize, c.BlockSize())
+
+	dst := make([]byte, Sm4BlockSize)
+	c.Encrypt(dst, plainText)
+	require.Equal(t, mustHex("681edf34d206965e86b3e94f536e4246"), dst)
+	c.Decrypt(dst, dst)
+	require.Equal(t, plainText, dst)
+
+	// 加密 1000000 次
+	copy(dst, plainText)
+	for i := 0; i < 1000000; i++ {
+		c.Encrypt(dst, dst)
+	}
+	require.Equal(t, mustHex("595298c7c6fd271f0402f804c33d3f66"), dst)
+
+	_, err = NewSm4Cipher(key[:15])
+	require.EqualError(t, err, "sm4: invalid key size 15")
+}
+
+func TestSm4EncryptDecrypt(t *testing.T) {
+	plainText := []byte("helloworld,this is golang language. welcome")
+	key := make([]byte, Sm4BlockSize)
+	_, err := io.ReadFull(rand.Reader, key)
+	require.NoError(t, err)
+
+	cipherText, err := Sm4CbcEncrypt(string(key), plainText)
+	require.NoError(t, err)
+	got, err := Sm4CbcDecrypt(string(key), cipherText)
+	require.NoError(t, err)
+	require.Equal(t, plainText, got)
+
+	cipherText, err = Sm4GcmEncrypt(string(key), plainText)
+	require.NoError(t, err)
+	got, err = Sm4GcmDecrypt(string(key), cipherText)
+	require.NoError(t, err)
+	require.Equal(t, plainText, got)
+
+	_, err = Sm4GcmDecrypt("0123456789abcdef", cipherText)
+	require.Error(t, err)
+	_, err = Sm4CbcEncrypt("short", plainText)
+	require.Error(t, err)
+	_, err = Sm4CbcDecrypt(string(key), "AAAA")
+	require.ErrorIs(t, err, ErrInputNotMoreABlock)
+}
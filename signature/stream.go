@@ -0,0 +1,128 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+// Encoding 摘要编码方式
+type Encoding int
+
+// Encoding defined
+const (
+	// EncodingHex hex encoded
+	EncodingHex Encoding = iota
+	// EncodingBase64 standard base64 encoded
+	EncodingBase64
+	// EncodingBase64URL url safe base64 encoded without padding
+	EncodingBase64URL
+)
+
+// EncodeToString 按指定方式编码摘要
+func (e Encoding) EncodeToString(b []byte) string {
+	switch e {
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(b)
+	default:
+		return hex.EncodeToString(b)
+	}
+}
+
+// HashReader 流式计算 r 的摘要, 并按 enc 编码.
+func HashReader(h func() hash.Hash, r io.Reader, enc Encoding) (string, error) {
+	return digestReader(h(), r, enc)
+}
+
+// HmacReader 流式计算 r 的 hmac, 并按 enc 编码.
+func HmacReader(h func() hash.Hash, key string, r io.Reader, enc Encoding) (string, error) {
+	return digestReader(hmac.New(h, []byte(key)), r, enc)
+}
+
+// Sha1Reader 流式 sha1.
+func Sha1Reader(r io.Reader, enc Encoding) (string, error) {
+	return HashReader(sha1.New, r, enc)
+}
+
+// Sha256Reader 流式 sha256.
+func Sha256Reader(r io.Reader, enc Encoding) (string, error) {
+	return HashReader(sha256.New, r, enc)
+}
+
+// Sha512Reader 流式 sha512.
+func Sha512Reader(r io.Reader, enc Encoding) (string, error) {
+	return HashReader(sha512.New, r, enc)
+}
+
+// HmacSha1Reader 流式 hmac sha1.
+func HmacSha1Reader(key string, r io.Reader, enc Encoding) (string, error) {
+	return HmacReader(sha1.New, key, r, enc)
+}
+
+// HmacSha256Reader 流式 hmac sha256.
+func HmacSha256Reader(key string, r io.Reader, enc Encoding) (string, error) {
+	return HmacReader(sha256.New, key, r, enc)
+}
+
+// HmacSha512Reader 流式 hmac sha512.
+func HmacSha512Reader(key string, r io.Reader, enc Encoding) (string, error) {
+	return HmacReader(sha512.New, key, r, enc)
+}
+
+func digestReader(h hash.Hash, r io.Reader, enc Encoding) (string, error) {
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return enc.EncodeToString(h.Sum(nil)), nil
+}
+
+// DigestReader 读取数据的同时计算摘要, 常用于签名中间件包装 http.Request.Body.
+// 数据读取完毕后, 通过 Sum 或 Digest 获取摘要.
+type DigestReader struct {
+	r io.Reader
+	h hash.Hash
+}
+
+// NewDigestReader 新建一个 DigestReader, 从 r 中读取的数据同时写入 h.
+func NewDigestReader(r io.Reader, h hash.Hash) *DigestReader {
+	return &DigestReader{r: r, h: h}
+}
+
+// NewHmacDigestReader 新建一个计算 hmac 的 DigestReader.
+func NewHmacDigestReader(r io.Reader, h func() hash.Hash, key string) *DigestReader {
+	return NewDigestReader(r, hmac.New(h, []byte(key)))
+}
+
+// Read implement io.Reader
+func (d *DigestReader) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	if n > 0 {
+		d.h.Write(p[:n])
+	}
+	return n, err
+}
+
+// Close implement io.Closer, close the underlying reader if it is an io.Closer.
+func (d *DigestReader) Close() error {
+	if c, ok := d.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Sum 已读取数据的摘要
+func (d *DigestReader) Sum() []byte {
+	return d.h.Sum(nil)
+}
+
+// Digest 已读取数据的摘要, 并按 enc 编码.
+func (d *DigestReader) Digest(enc Encoding) string {
+	return enc.EncodeToString(d.Sum())
+}
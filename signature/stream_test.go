@@ -0,0 +1,69 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashReader(t *testing.T) {
+	str := "helloworld,this is golang language. welcome"
+
+	got, err := Sha1Reader(strings.NewReader(str), EncodingHex)
+	require.NoError(t, err)
+	require.Equal(t, HexSha1(str), got)
+
+	got, err = Sha256Reader(strings.NewReader(str), EncodingHex)
+	require.NoError(t, err)
+	require.Equal(t, HexSha256(str), got)
+
+	got, err = Sha512Reader(strings.NewReader(str), EncodingHex)
+	require.NoError(t, err)
+	require.Equal(t, HexSha512(str), got)
+
+	got, err = HmacSha1Reader("key", strings.NewReader(str), EncodingBase64)
+	require.NoError(t, err)
+	require.Equal(t, HmacSha1("key", str), got)
+
+	got, err = HmacSha256Reader("key", strings.NewReader(str), EncodingBase64)
+	require.NoError(t, err)
+	require.Equal(t, HmacSha256("key", str), got)
+
+	got, err = HmacSha512Reader("key", strings.NewReader(str), EncodingBase64)
+	require.NoError(t, err)
+	require.Equal(t, Hmac512("key", str), got)
+
+	got, err = HmacSha256Reader("key", strings.NewReader(str), EncodingBase64URL)
+	require.NoError(t, err)
+	require.NotContains(t, got, "=")
+}
+
+func TestDigestReader(t *testing.T) {
+	str := strings.Repeat("helloworld,this is golang language. welcome", 1024)
+
+	dr := NewHmacDigestReader(io.NopCloser(strings.NewReader(str)), sha256.New, "key")
+	buf := &bytes.Buffer{}
+	_, err := io.Copy(buf, dr)
+	require.NoError(t, err)
+	require.NoError(t, dr.Close())
+	require.Equal(t, str, buf.String())
+	require.Equal(t, HmacSha256("key", str), dr.Digest(EncodingBase64))
+
+	dr = NewDigestReader(strings.NewReader(str), sha256.New())
+	_, err = io.ReadAll(dr)
+	require.NoError(t, err)
+	require.NoError(t, dr.Close())
+	require.Equal(t, HexSha256(str), dr.Digest(EncodingHex))
+}
+
+func BenchmarkSha256Reader(b *testing.B) {
+	data := bytes.Repeat([]byte("a"), 1<<20)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		_, _ = Sha256Reader(bytes.NewReader(data), EncodingHex)
+	}
+}